@@ -0,0 +1,94 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package amount parses and formats the quantities and prices sent to and
+// received from Prime. Values are exact decimals: the only accepted input is
+// plain notation such as 0.25 or 30000, and the wire form never carries an
+// exponent or trailing zeros.
+package amount
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// MaxScale is the most decimal places a quantity or price may carry.
+const MaxScale = 18
+
+var (
+	ErrSyntax      = errors.New("must be a plain decimal number, e.g. 0.25")
+	ErrNotPositive = errors.New("must be greater than zero")
+	ErrTooPrecise  = fmt.Errorf("must have at most %d decimal places", MaxScale)
+)
+
+// Parse reads a decimal in plain notation: an optional leading minus, at least
+// one digit, and an optional fractional part. Exponents, signs other than a
+// leading minus, NaN and Inf are rejected.
+func Parse(s string) (decimal.Decimal, error) {
+	digits, point := 0, -1
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c >= '0' && c <= '9':
+			digits++
+		case c == '-' && i == 0:
+		case c == '.' && point < 0 && digits > 0:
+			point = i
+		default:
+			return decimal.Decimal{}, ErrSyntax
+		}
+	}
+	if digits == 0 || point == len(s)-1 {
+		return decimal.Decimal{}, ErrSyntax
+	}
+	if point >= 0 && len(s)-point-1 > MaxScale {
+		return decimal.Decimal{}, ErrTooPrecise
+	}
+	return decimal.NewFromString(s)
+}
+
+// Positive parses s and requires it to be greater than zero.
+func Positive(s string) (decimal.Decimal, error) {
+	d, err := Parse(s)
+	if err != nil {
+		return d, err
+	}
+	if !d.IsPositive() {
+		return d, ErrNotPositive
+	}
+	return d, nil
+}
+
+// Normalize parses a positive value and returns its wire form.
+func Normalize(s string) (string, error) {
+	d, err := Positive(s)
+	if err != nil {
+		return "", err
+	}
+	return Format(d), nil
+}
+
+// Format renders d in plain notation without trailing zeros.
+func Format(d decimal.Decimal) string {
+	return d.String()
+}
+
+// Div divides a by b, rounding to MaxScale decimal places. It is only used
+// for averages; sums and products are exact.
+func Div(a, b decimal.Decimal) decimal.Decimal {
+	return a.DivRound(b, MaxScale)
+}
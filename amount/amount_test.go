@@ -0,0 +1,87 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package amount
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestParseRejects(t *testing.T) {
+	tests := map[string]error{
+		"1e3":                   ErrSyntax,
+		"NaN":                   ErrSyntax,
+		"Inf":                   ErrSyntax,
+		"+5":                    ErrSyntax,
+		"":                      ErrSyntax,
+		"-":                     ErrSyntax,
+		".5":                    ErrSyntax,
+		"5.":                    ErrSyntax,
+		"1.2.3":                 ErrSyntax,
+		" 1":                    ErrSyntax,
+		"0x10":                  ErrSyntax,
+		"0.1000000000000000001": ErrTooPrecise,
+	}
+	for input, want := range tests {
+		if _, err := Parse(input); !errors.Is(err, want) {
+			t.Errorf("Parse(%q): expected %v, got %v", input, want, err)
+		}
+	}
+}
+
+func TestPositive(t *testing.T) {
+	for _, input := range []string{"-5", "0", "0.000"} {
+		if _, err := Positive(input); !errors.Is(err, ErrNotPositive) {
+			t.Errorf("Positive(%q): expected %v, got %v", input, ErrNotPositive, err)
+		}
+	}
+	if d, err := Positive("0.000000000000000001"); err != nil || d.Exponent() != -MaxScale {
+		t.Errorf("Expected the smallest value at MaxScale to parse, got %v %v", d, err)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"30000":      "30000",
+		"030000.00":  "30000",
+		"0.1000":     "0.1",
+		"1.50":       "1.5",
+		"0.00000001": "0.00000001",
+	}
+	for input, want := range tests {
+		got, err := Normalize(input)
+		if err != nil || got != want {
+			t.Errorf("Normalize(%q): expected %s, got %s (%v)", input, want, got, err)
+		}
+	}
+}
+
+func TestArithmeticIsExact(t *testing.T) {
+	sum := decimal.Zero
+	tenth, _ := Parse("0.1")
+	for i := 0; i < 10; i++ {
+		sum = sum.Add(tenth)
+	}
+	if !sum.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("Expected ten times 0.1 to be exactly 1, got %s", sum)
+	}
+	if got := Format(Div(decimal.NewFromInt(1), decimal.NewFromInt(3))); got != "0.333333333333333333" {
+		t.Errorf("Expected 1/3 rounded to %d places, got %s", MaxScale, got)
+	}
+}
@@ -0,0 +1,158 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"prime-fix-go/events"
+)
+
+// writeTimeout bounds how long a single event may take to reach a client.
+const writeTimeout = 10 * time.Second
+
+// reset is sent first when the requested sequence belongs to an earlier run
+// of the client, whose numbering has since restarted; every retained event
+// is then replayed.
+type reset struct {
+	Type  string `json:"type"`
+	Epoch string `json:"epoch"`
+}
+
+// gap is sent before a replay when events from the requested sequence onwards
+// are no longer retained.
+type gap struct {
+	Type   string `json:"type"`
+	From   uint64 `json:"from"`
+	Missed uint64 `json:"missed"`
+}
+
+// streamEvents upgrades to a WebSocket and sends every matching event as a
+// JSON text message. The query selects what is sent:
+//
+//	symbol  comma-separated symbols; events without a symbol always match
+//	type    comma-separated event types (order, fill, quote, quote_ack, reject, session)
+//	from    replay retained events from this sequence number before going live
+//	epoch   the epoch of the events the client saw, checked against from
+//
+// A client that falls too far behind is disconnected and can reconnect with
+// from set to one past the last sequence number it received and epoch to its
+// epoch.
+func (s *Server) streamEvents(w http.ResponseWriter, r *http.Request) {
+	filter, epoch, from, err := parseEventQuery(r)
+	if err != nil {
+		writeJson(w, http.StatusBadRequest, errorBody{Error: err.Error()})
+		return
+	}
+	bus := s.app.Events()
+	// The token already guards this endpoint, so any Origin is accepted; a nil
+	// Handshake also lets non-browser clients connect without one.
+	websocket.Server{Handler: func(ws *websocket.Conn) {
+		defer ws.Close()
+		sub := bus.Resume(filter, epoch, from)
+		defer sub.Close()
+
+		// Anything the client sends is ignored; reading detects a disconnect.
+		closed := make(chan struct{})
+		go func() {
+			defer close(closed)
+			var discard string
+			for websocket.Message.Receive(ws, &discard) == nil {
+			}
+		}()
+
+		if sub.Reset {
+			if !send(ws, reset{Type: "reset", Epoch: bus.Epoch()}) {
+				return
+			}
+			from = 1
+		}
+		if sub.Missed > 0 && !send(ws, gap{Type: "gap", From: from, Missed: sub.Missed}) {
+			return
+		}
+		for _, e := range sub.Replay {
+			if !send(ws, e) {
+				return
+			}
+		}
+		for {
+			select {
+			case e, ok := <-sub.Events:
+				if !ok {
+					if sub.Dropped() {
+						log.Printf("event stream for %s dropped: client fell behind", r.RemoteAddr)
+					}
+					return
+				}
+				if !send(ws, e) {
+					return
+				}
+			case <-closed:
+				return
+			case <-r.Context().Done():
+				return
+			}
+		}
+	}}.ServeHTTP(w, r)
+}
+
+func send(ws *websocket.Conn, v any) bool {
+	_ = ws.SetWriteDeadline(time.Now().Add(writeTimeout))
+	return websocket.JSON.Send(ws, v) == nil
+}
+
+func parseEventQuery(r *http.Request) (events.Filter, string, uint64, error) {
+	query := r.URL.Query()
+	filter := events.Filter{Symbols: splitList(query["symbol"]), Types: splitList(query["type"])}
+	for _, typ := range filter.Types {
+		known := false
+		for _, t := range events.Types {
+			known = known || t == typ
+		}
+		if !known {
+			return filter, "", 0, fmt.Errorf("unknown event type %q (%s)", typ, strings.Join(events.Types, ", "))
+		}
+	}
+	var from uint64
+	if v := query.Get("from"); v != "" {
+		var err error
+		if from, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return filter, "", 0, fmt.Errorf("invalid from %q: expected a sequence number", v)
+		}
+	}
+	return filter, query.Get("epoch"), from, nil
+}
+
+// splitList flattens repeated and comma-separated query values.
+func splitList(values []string) []string {
+	var list []string
+	for _, v := range values {
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+	return list
+}
@@ -0,0 +1,134 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"prime-fix-go/constants"
+	"prime-fix-go/events"
+	"prime-fix-go/fixclient"
+	"prime-fix-go/store"
+)
+
+func newEventServer(t *testing.T) (*fixclient.FixApp, *httptest.Server) {
+	t.Helper()
+	app := fixclient.NewFixApp(constants.NewConfig(), store.NewMemoryStore())
+	s, err := NewServer(app, "", testToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(s.Handler())
+	t.Cleanup(ts.Close)
+	return app, ts
+}
+
+func dial(t *testing.T, ts *httptest.Server, query string) *websocket.Conn {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(ts.URL, "http") + "/v1/events?access_token=" + testToken + query
+	ws, err := websocket.Dial(url, "", "http://localhost/")
+	if err != nil {
+		t.Fatalf("Dial returned error: %v", err)
+	}
+	t.Cleanup(func() { ws.Close() })
+	return ws
+}
+
+func receive(t *testing.T, ws *websocket.Conn) map[string]any {
+	t.Helper()
+	_ = ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var msg map[string]any
+	if err := websocket.JSON.Receive(ws, &msg); err != nil {
+		t.Fatalf("Receive returned error: %v", err)
+	}
+	return msg
+}
+
+func TestEventStreamReplaysAndFilters(t *testing.T) {
+	app, ts := newEventServer(t)
+	bus := app.Events()
+	bus.Publish(events.TypeFill, "BTC-USD", nil)
+	bus.Publish(events.TypeFill, "ETH-USD", nil)
+	bus.Publish(events.TypeOrder, "BTC-USD", nil)
+
+	ws := dial(t, ts, "&symbol=BTC-USD&type=fill,order&from=1")
+	for _, want := range []float64{1, 3} {
+		if msg := receive(t, ws); msg["seq"] != want {
+			t.Errorf("Expected replayed event %v, got %v", want, msg)
+		}
+	}
+
+	// Replay is sent after the subscription is registered, so live events
+	// published now are delivered.
+	bus.Publish(events.TypeQuote, "BTC-USD", nil)
+	bus.Publish(events.TypeFill, "BTC-USD", map[string]string{"execId": "e1"})
+	if msg := receive(t, ws); msg["seq"] != float64(5) || msg["type"] != events.TypeFill {
+		t.Errorf("Expected live fill 5, got %v", msg)
+	}
+}
+
+func TestEventStreamReportsGap(t *testing.T) {
+	app, ts := newEventServer(t)
+	for i := 0; i < events.DefaultHistory+2; i++ {
+		app.Events().Publish(events.TypeOrder, "BTC-USD", nil)
+	}
+	ws := dial(t, ts, "&from=1")
+	msg := receive(t, ws)
+	if msg["type"] != "gap" || msg["missed"] != float64(2) {
+		t.Errorf("Expected a gap of 2 events, got %v", msg)
+	}
+	if msg := receive(t, ws); msg["seq"] != float64(3) {
+		t.Errorf("Expected replay to resume at 3, got %v", msg)
+	}
+}
+
+func TestEventStreamReportsReset(t *testing.T) {
+	app, ts := newEventServer(t)
+	bus := app.Events()
+	bus.Publish(events.TypeOrder, "BTC-USD", nil)
+	bus.Publish(events.TypeOrder, "BTC-USD", nil)
+
+	for _, query := range []string{"&from=50", "&from=2&epoch=earlier"} {
+		ws := dial(t, ts, query)
+		if msg := receive(t, ws); msg["type"] != "reset" || msg["epoch"] != bus.Epoch() {
+			t.Errorf("%s: expected a reset to epoch %s, got %v", query, bus.Epoch(), msg)
+		}
+		if msg := receive(t, ws); msg["seq"] != float64(1) || msg["epoch"] != bus.Epoch() {
+			t.Errorf("%s: expected replay from 1, got %v", query, msg)
+		}
+	}
+}
+
+func TestEventStreamRejectsBadQuery(t *testing.T) {
+	_, ts := newEventServer(t)
+	for _, query := range []string{"type=fills", "from=abc"} {
+		resp, body := do(t, ts, "GET", "/v1/events?"+query, testToken, "")
+		if resp.StatusCode != http.StatusBadRequest || body.Error == "" {
+			t.Errorf("Expected 400 for %q, got %d %v", query, resp.StatusCode, body)
+		}
+	}
+	resp, _ := do(t, ts, "GET", "/v1/events?access_token=wrong", "", "")
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected 401 for a wrong access_token, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,290 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package api serves a FixApp over a local HTTP/JSON API so the client can run
+// headless as a service.
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"prime-fix-go/builder"
+	"prime-fix-go/fixclient"
+	"prime-fix-go/instruments"
+	"prime-fix-go/risk"
+)
+
+// DefaultAddr keeps the API on the loopback interface unless configured
+// otherwise.
+const DefaultAddr = "127.0.0.1:8642"
+
+// maxBodyBytes caps the size of a request body.
+const maxBodyBytes = 1 << 20
+
+// Server exposes order entry, order state and RFQs over HTTP, and streams
+// events over a WebSocket. Every request
+// must carry the configured token as a bearer token.
+type Server struct {
+	app   *fixclient.FixApp
+	token string
+	http  *http.Server
+}
+
+// NewServer returns a server for app listening on addr. The token must not be
+// empty.
+func NewServer(app *fixclient.FixApp, addr, token string) (*Server, error) {
+	if token == "" {
+		return nil, fmt.Errorf("an API token is required")
+	}
+	if addr == "" {
+		addr = DefaultAddr
+	}
+	s := &Server{app: app, token: token}
+	s.http = &http.Server{
+		Addr:              addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return s, nil
+}
+
+// Handler returns the API routes behind token authentication.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/orders", s.placeOrder)
+	mux.HandleFunc("GET /v1/orders", s.listOrders)
+	mux.HandleFunc("GET /v1/orders/{clOrdId}", s.getOrder)
+	mux.HandleFunc("POST /v1/orders/{clOrdId}/status", s.requestStatus)
+	mux.HandleFunc("PATCH /v1/orders/{clOrdId}", s.replaceOrder)
+	mux.HandleFunc("DELETE /v1/orders/{clOrdId}", s.cancelOrder)
+	mux.HandleFunc("GET /v1/fills", s.listFills)
+	mux.HandleFunc("POST /v1/rfqs", s.sendRfq)
+	mux.HandleFunc("GET /v1/rfqs", s.listRfqs)
+	mux.HandleFunc("GET /v1/quotes", s.listQuotes)
+	mux.HandleFunc("POST /v1/quotes/{quoteId}/accept", s.acceptQuote)
+	mux.HandleFunc("GET /v1/events", s.streamEvents)
+	return s.authenticate(mux)
+}
+
+// ListenAndServe serves until Shutdown is called.
+func (s *Server) ListenAndServe() error {
+	log.Printf("API listening on %s", s.http.Addr)
+	if err := s.http.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown stops accepting requests and waits for those in flight.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.http.Shutdown(ctx)
+}
+
+// authenticate checks the bearer token. Browsers cannot set headers on a
+// WebSocket, so the event stream also accepts it as the access_token query
+// parameter.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found && r.URL.Path == "/v1/events" {
+			token = r.URL.Query().Get("access_token")
+			found = token != ""
+		}
+		if !found || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeJson(w, http.StatusUnauthorized, errorBody{Error: "missing or invalid token"})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) placeOrder(w http.ResponseWriter, r *http.Request) {
+	var req builder.NewOrderRequest
+	if !readJson(w, r, &req) {
+		return
+	}
+	if req.ExecInst != "" {
+		execInst, err := builder.ParseExecInst(req.ExecInst)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		req.ExecInst = execInst
+	}
+	order, err := s.app.PlaceOrder(req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJson(w, http.StatusCreated, order)
+}
+
+func (s *Server) listOrders(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	symbol := r.URL.Query().Get("symbol")
+	orders := s.app.Orders()
+	filtered := orders[:0]
+	for _, o := range orders {
+		if (status == "" || strings.EqualFold(o.Status, status)) && (symbol == "" || strings.EqualFold(o.Symbol, symbol)) {
+			filtered = append(filtered, o)
+		}
+	}
+	writeJson(w, http.StatusOK, filtered)
+}
+
+func (s *Server) getOrder(w http.ResponseWriter, r *http.Request) {
+	clOrdId := r.PathValue("clOrdId")
+	order, ok := s.app.Order(clOrdId)
+	if !ok {
+		writeJson(w, http.StatusNotFound, errorBody{Error: "unknown ClOrdId " + clOrdId})
+		return
+	}
+	writeJson(w, http.StatusOK, order)
+}
+
+func (s *Server) requestStatus(w http.ResponseWriter, r *http.Request) {
+	order, err := s.app.RequestStatus(r.PathValue("clOrdId"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJson(w, http.StatusAccepted, order)
+}
+
+func (s *Server) replaceOrder(w http.ResponseWriter, r *http.Request) {
+	var change fixclient.Amendment
+	if !readJson(w, r, &change) {
+		return
+	}
+	order, err := s.app.ReplaceOrder(r.PathValue("clOrdId"), change)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJson(w, http.StatusAccepted, order)
+}
+
+func (s *Server) cancelOrder(w http.ResponseWriter, r *http.Request) {
+	order, err := s.app.CancelOrder(r.PathValue("clOrdId"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJson(w, http.StatusAccepted, order)
+}
+
+func (s *Server) listFills(w http.ResponseWriter, r *http.Request) {
+	var since time.Time
+	if v := r.URL.Query().Get("since"); v != "" {
+		var ok bool
+		if since, ok = fixclient.ParseSince(v); !ok {
+			writeJson(w, http.StatusBadRequest, errorBody{Error: fmt.Sprintf("invalid since %q (today, a duration, a date or an RFC 3339 time)", v)})
+			return
+		}
+	}
+	writeJson(w, http.StatusOK, s.app.Fills(r.URL.Query().Get("symbol"), since))
+}
+
+func (s *Server) sendRfq(w http.ResponseWriter, r *http.Request) {
+	var req builder.QuoteRequest
+	if !readJson(w, r, &req) {
+		return
+	}
+	rfq, err := s.app.SendRfq(req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJson(w, http.StatusCreated, rfq)
+}
+
+func (s *Server) listRfqs(w http.ResponseWriter, _ *http.Request) {
+	writeJson(w, http.StatusOK, s.app.Rfqs())
+}
+
+func (s *Server) listQuotes(w http.ResponseWriter, _ *http.Request) {
+	writeJson(w, http.StatusOK, s.app.Quotes())
+}
+
+func (s *Server) acceptQuote(w http.ResponseWriter, r *http.Request) {
+	order, err := s.app.AcceptQuote(r.PathValue("quoteId"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJson(w, http.StatusCreated, order)
+}
+
+// errorBody is the JSON returned with every failed request. Fields lists the
+// invalid request fields and Rule the risk check that blocked an order.
+type errorBody struct {
+	Error  string                `json:"error"`
+	Fields []*builder.FieldError `json:"fields,omitempty"`
+	Rule   string                `json:"rule,omitempty"`
+}
+
+func readJson(w http.ResponseWriter, r *http.Request, v any) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		writeJson(w, http.StatusBadRequest, errorBody{Error: "invalid JSON body: " + err.Error()})
+		return false
+	}
+	return true
+}
+
+// writeError maps an order operation error to its HTTP status.
+func writeError(w http.ResponseWriter, err error) {
+	body := errorBody{Error: err.Error()}
+	status := http.StatusBadRequest
+
+	var invalid *builder.ValidationError
+	var violation *instruments.Violation
+	var blocked *risk.Violation
+	switch {
+	case errors.As(err, &invalid):
+		body.Fields = invalid.Fields
+	case errors.As(err, &violation):
+		body.Fields = []*builder.FieldError{{Field: violation.Field, Reason: violation.Reason}}
+	case errors.As(err, &blocked):
+		status = http.StatusUnprocessableEntity
+		body.Rule = blocked.Rule
+	case errors.Is(err, fixclient.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, fixclient.ErrConflict):
+		status = http.StatusConflict
+	case errors.Is(err, fixclient.ErrNotSent):
+		status = http.StatusServiceUnavailable
+	}
+	writeJson(w, status, body)
+}
+
+func writeJson(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Println("API write error:", err)
+	}
+}
@@ -0,0 +1,146 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"prime-fix-go/constants"
+	"prime-fix-go/fixclient"
+	"prime-fix-go/store"
+)
+
+const testToken = "secret"
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	app := fixclient.NewFixApp(constants.NewConfig(), store.NewMemoryStore())
+	s, err := NewServer(app, "", testToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(s.Handler())
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func do(t *testing.T, ts *httptest.Server, method, path, token, body string) (*http.Response, errorBody) {
+	t.Helper()
+	req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var e errorBody
+	_ = json.NewDecoder(resp.Body).Decode(&e)
+	return resp, e
+}
+
+func TestNewServerRequiresToken(t *testing.T) {
+	if _, err := NewServer(nil, "", ""); err == nil {
+		t.Error("Expected NewServer to refuse an empty token")
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	ts := newTestServer(t)
+	for _, token := range []string{"", "wrong"} {
+		if resp, _ := do(t, ts, "GET", "/v1/orders", token, ""); resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("Expected 401 for token %q, got %d", token, resp.StatusCode)
+		}
+	}
+	if resp, _ := do(t, ts, "GET", "/v1/orders", testToken, ""); resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected 200 with the token, got %d", resp.StatusCode)
+	}
+}
+
+func TestPlaceOrderValidation(t *testing.T) {
+	ts := newTestServer(t)
+	resp, body := do(t, ts, "POST", "/v1/orders", testToken,
+		`{"symbol": "BTC-USD", "ordType": "LIMIT", "side": "HOLD", "qtyType": "BASE", "qty": "1e3"}`)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("Expected 400, got %d", resp.StatusCode)
+	}
+	fields := make(map[string]bool)
+	for _, f := range body.Fields {
+		fields[f.Field] = true
+	}
+	for _, field := range []string{"side", "qty", "price"} {
+		if !fields[field] {
+			t.Errorf("Expected a %s error, got %+v", field, body.Fields)
+		}
+	}
+
+	if resp, _ := do(t, ts, "POST", "/v1/orders", testToken, `{"symbol": "BTC-USD", "quantity": "1"}`); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an unknown field, got %d", resp.StatusCode)
+	}
+}
+
+func TestPlaceOrderWithoutSession(t *testing.T) {
+	ts := newTestServer(t)
+	resp, body := do(t, ts, "POST", "/v1/orders", testToken,
+		`{"symbol": "BTC-USD", "ordType": "MARKET", "side": "BUY", "qtyType": "BASE", "qty": "0.1"}`)
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 without a FIX session, got %d (%s)", resp.StatusCode, body.Error)
+	}
+}
+
+func TestUnknownOrderAndQuote(t *testing.T) {
+	ts := newTestServer(t)
+	requests := []struct{ method, path, body string }{
+		{"GET", "/v1/orders/nope", ""},
+		{"DELETE", "/v1/orders/nope", ""},
+		{"PATCH", "/v1/orders/nope", `{"price": "100"}`},
+		{"POST", "/v1/orders/nope/status", ""},
+		{"POST", "/v1/quotes/nope/accept", ""},
+	}
+	for _, r := range requests {
+		if resp, _ := do(t, ts, r.method, r.path, testToken, r.body); resp.StatusCode != http.StatusNotFound {
+			t.Errorf("%s %s: expected 404, got %d", r.method, r.path, resp.StatusCode)
+		}
+	}
+}
+
+func TestListFills(t *testing.T) {
+	ts := newTestServer(t)
+	if resp, _ := do(t, ts, "GET", "/v1/fills?since=yesterday", testToken, ""); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid since, got %d", resp.StatusCode)
+	}
+
+	req, _ := http.NewRequest("GET", ts.URL+"/v1/fills?symbol=BTC-USD&since=today", nil)
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var fills []json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&fills); err != nil || fills == nil {
+		t.Errorf("Expected an empty JSON array, got %v %v", fills, err)
+	}
+}
@@ -0,0 +1,65 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package builder
+
+import (
+	"testing"
+
+	"prime-fix-go/constants"
+)
+
+func TestParseExecInst(t *testing.T) {
+	execInst, err := ParseExecInst("post_only, 6")
+	if err != nil {
+		t.Fatalf("ParseExecInst returned error: %v", err)
+	}
+	if execInst != "A 6" {
+		t.Errorf("Expected ExecInst \"A 6\", got %q", execInst)
+	}
+	if !HasExecInst(execInst, constants.ExecInstPostOnly) {
+		t.Error("Expected post-only in parsed ExecInst")
+	}
+
+	if _, err := ParseExecInst("MAKER"); err == nil {
+		t.Error("Expected error for an unknown ExecInst name")
+	}
+}
+
+func TestSetExecInstPostOnly(t *testing.T) {
+	config := constants.NewConfig()
+	msg, err := BuildNew("BTC-USD", "LIMIT", "BUY", "BASE", "0.1", "30000", "test-portfolio", config)
+	if err != nil {
+		t.Fatalf("BuildNew returned error: %v", err)
+	}
+	if err := SetExecInst(msg, constants.ExecInstPostOnly); err != nil {
+		t.Fatalf("SetExecInst returned error: %v", err)
+	}
+	execInst, _ := msg.Body.GetString(constants.TagExecInst)
+	if execInst != constants.ExecInstPostOnly {
+		t.Errorf("Expected ExecInst %s, got %s", constants.ExecInstPostOnly, execInst)
+	}
+
+	ioc, _ := BuildNew("BTC-USD", "LIMIT", "BUY", "BASE", "0.1", "30000", "test-portfolio", config, "IOC")
+	if err := SetExecInst(ioc, constants.ExecInstPostOnly); err == nil {
+		t.Error("Expected error for a post-only IOC order")
+	}
+
+	market, _ := BuildNew("BTC-USD", "MARKET", "BUY", "BASE", "0.1", "", "test-portfolio", config)
+	if err := SetExecInst(market, constants.ExecInstPostOnly); err == nil {
+		t.Error("Expected error for a post-only MARKET order")
+	}
+}
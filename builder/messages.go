@@ -22,15 +22,20 @@ import (
 	"strings"
 	"time"
 
+	"prime-fix-go/amount"
 	"prime-fix-go/constants"
 	"prime-fix-go/model"
 
 	"github.com/quickfixgo/quickfix"
 )
 
-func BuildNew(
-	symbol, ordType, side, qtyType, qty, price, portfolio string, config *constants.Config, vwapParams ...string,
-) (*quickfix.Message, error) {
+// BuildNewOrder validates req and builds the New Order Single for it.
+func BuildNewOrder(req NewOrderRequest, config *constants.Config) (*quickfix.Message, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	req = req.Normalize()
+
 	m := quickfix.NewMessage()
 	m.Header.SetField(constants.TagMsgType, quickfix.FIXString(constants.MsgTypeNew))
 	m.Header.SetField(constants.TagSenderCompId, quickfix.FIXString(config.SenderCompId))
@@ -38,70 +43,96 @@ func BuildNew(
 	m.Header.SetField(constants.TagSendingTime, quickfix.FIXString(time.Now().UTC().Format(constants.FixTimeFormat)))
 
 	clId := fmt.Sprintf("%d", time.Now().UnixNano())
-	m.Body.SetField(constants.TagAccount, quickfix.FIXString(portfolio))
+	m.Body.SetField(constants.TagAccount, quickfix.FIXString(req.Portfolio))
 	m.Body.SetField(constants.TagClOrdId, quickfix.FIXString(clId))
-	m.Body.SetField(constants.TagSymbol, quickfix.FIXString(symbol))
+	m.Body.SetField(constants.TagSymbol, quickfix.FIXString(req.Symbol))
 
 	// Set quantity based on user preference (BASE or QUOTE)
-	if strings.EqualFold(qtyType, "BASE") {
-		m.Body.SetField(constants.TagOrderQty, quickfix.FIXString(qty))
-	} else if strings.EqualFold(qtyType, "QUOTE") {
-		m.Body.SetField(constants.TagCashOrderQty, quickfix.FIXString(qty))
+	if strings.EqualFold(req.QtyType, "BASE") {
+		m.Body.SetField(constants.TagOrderQty, quickfix.FIXString(req.Qty))
 	} else {
-		return nil, fmt.Errorf("invalid quantity type: %s (must be BASE or QUOTE)", qtyType)
+		m.Body.SetField(constants.TagCashOrderQty, quickfix.FIXString(req.Qty))
 	}
 
-	if strings.EqualFold(ordType, constants.OrdTypeLimit) {
+	switch strings.ToUpper(req.OrdType) {
+	case constants.OrdTypeLimit:
 		m.Body.SetField(constants.TagOrdType, quickfix.FIXString(constants.OrdTypeLimitFix))
-		m.Body.SetField(constants.TagTimeInForce, quickfix.FIXString(constants.TimeInForceDay))
-		m.Body.SetField(constants.TagPx, quickfix.FIXString(price))
+		m.Body.SetField(constants.TagTimeInForce, quickfix.FIXString(timeInForceCode(req.TimeInForce, req.ExpireTime)))
+		if req.ExpireTime != "" {
+			m.Body.SetField(constants.TagExpireTime, quickfix.FIXString(fixTime(req.ExpireTime)))
+		}
+		m.Body.SetField(constants.TagPx, quickfix.FIXString(req.Price))
 		m.Body.SetField(constants.TagTargetStrategy, quickfix.FIXString(constants.TargetStrategyLimit))
-	} else if strings.EqualFold(ordType, constants.OrdTypeVwap) {
+	case constants.OrdTypeVwap:
 		m.Body.SetField(constants.TagOrdType, quickfix.FIXString(constants.OrdTypeVwapFix))
 		m.Body.SetField(constants.TagTimeInForce, quickfix.FIXString(constants.TimeInForceGtd))
-		m.Body.SetField(constants.TagPx, quickfix.FIXString(price))
+		m.Body.SetField(constants.TagPx, quickfix.FIXString(req.Price))
 		m.Body.SetField(constants.TagTargetStrategy, quickfix.FIXString(constants.TargetStrategyVwap))
-
-		if len(vwapParams) > 0 && vwapParams[0] != "" {
-			effectiveTime, err := time.Parse("2006-01-02T15:04:05Z", vwapParams[0])
-			if err == nil {
-				m.Body.SetField(constants.TagStartTime, quickfix.FIXString(effectiveTime.Format(constants.FixTimeFormat)))
-			} else {
-				m.Body.SetField(constants.TagStartTime, quickfix.FIXString(vwapParams[0]))
-			}
-		}
-
-		hasParticipationRate := len(vwapParams) > 1 && vwapParams[1] != ""
-		hasExpireTime := len(vwapParams) > 2 && vwapParams[2] != ""
-
-		if hasParticipationRate {
-			m.Body.SetField(constants.TagParticipationRate, quickfix.FIXString(vwapParams[1]))
+		if req.StartTime != "" {
+			m.Body.SetField(constants.TagStartTime, quickfix.FIXString(fixTime(req.StartTime)))
 		}
-		if hasExpireTime {
-			expireTime, err := time.Parse("2006-01-02T15:04:05Z", vwapParams[2])
-			if err == nil {
-				m.Body.SetField(constants.TagExpireTime, quickfix.FIXString(expireTime.Format(constants.FixTimeFormat)))
-			} else {
-				m.Body.SetField(constants.TagExpireTime, quickfix.FIXString(vwapParams[2]))
-			}
-		} else {
-			return nil, fmt.Errorf("expire time is required for VWAP orders")
+		if req.ParticipationRate != "" {
+			m.Body.SetField(constants.TagParticipationRate, quickfix.FIXString(req.ParticipationRate))
 		}
-	} else {
+		m.Body.SetField(constants.TagExpireTime, quickfix.FIXString(fixTime(req.ExpireTime)))
+	case constants.OrdTypeStopLimit:
+		m.Body.SetField(constants.TagOrdType, quickfix.FIXString(constants.OrdTypeStopLimitFix))
+		m.Body.SetField(constants.TagTimeInForce, quickfix.FIXString(constants.TimeInForceGtc))
+		m.Body.SetField(constants.TagPx, quickfix.FIXString(req.Price))
+		m.Body.SetField(constants.TagStopPx, quickfix.FIXString(req.StopPx))
+		m.Body.SetField(constants.TagTargetStrategy, quickfix.FIXString(constants.TargetStrategyStopLimit))
+	case constants.OrdTypeTwap:
+		setTwapOrdType(m, req.Price)
+		m.Body.SetField(constants.TagTimeInForce, quickfix.FIXString(constants.TimeInForceGtd))
+		m.Body.SetField(constants.TagTargetStrategy, quickfix.FIXString(constants.TargetStrategyTwap))
+		m.Body.SetField(constants.TagStartTime, quickfix.FIXString(fixTime(req.StartTime)))
+		m.Body.SetField(constants.TagExpireTime, quickfix.FIXString(fixTime(req.ExpireTime)))
+	default:
 		m.Body.SetField(constants.TagOrdType, quickfix.FIXString(constants.OrdTypeMarketFix))
 		m.Body.SetField(constants.TagTimeInForce, quickfix.FIXString(constants.TimeInForceIoc))
 		m.Body.SetField(constants.TagTargetStrategy, quickfix.FIXString(constants.TargetStrategyMarket))
 	}
 
-	if strings.EqualFold(side, constants.SideBuy) {
-		m.Body.SetField(constants.TagSide, quickfix.FIXString(constants.SideBuyFix))
-	} else {
-		m.Body.SetField(constants.TagSide, quickfix.FIXString(constants.SideSellFix))
+	m.Body.SetField(constants.TagSide, quickfix.FIXString(fixSide(req.Side)))
+	if req.ExecInst != "" {
+		m.Body.SetField(constants.TagExecInst, quickfix.FIXString(req.ExecInst))
 	}
 
 	return m, nil
 }
 
+// BuildNew builds a New Order Single from positional arguments. algoParams
+// carry the fields specific to the order type: start time, participation rate
+// and expire time for VWAP; start time and expire time for TWAP; the stop price
+// for STOP_LIMIT; the time in force and expire time for LIMIT.
+//
+// Deprecated: use BuildNewOrder with a NewOrderRequest.
+func BuildNew(
+	symbol, ordType, side, qtyType, qty, price, portfolio string, config *constants.Config, algoParams ...string,
+) (*quickfix.Message, error) {
+	req := NewOrderRequest{
+		Portfolio: portfolio,
+		Symbol:    symbol,
+		OrdType:   ordType,
+		Side:      side,
+		QtyType:   qtyType,
+		Qty:       qty,
+		Price:     price,
+	}
+	param := func(i int) string { return utils.GetOptional(algoParams, i) }
+	switch strings.ToUpper(ordType) {
+	case constants.OrdTypeLimit:
+		req.TimeInForce, req.ExpireTime = param(0), param(1)
+	case constants.OrdTypeStopLimit:
+		req.StopPx = param(0)
+	case constants.OrdTypeVwap:
+		req.StartTime, req.ParticipationRate, req.ExpireTime = param(0), param(1), param(2)
+	case constants.OrdTypeTwap:
+		req.StartTime, req.ExpireTime = param(0), param(1)
+	}
+	return BuildNewOrder(req, config)
+}
+
 func BuildStatus(clId, ordId, side, symbol string, config *constants.Config) *quickfix.Message {
 	m := quickfix.NewMessage()
 	m.Header.SetField(constants.TagMsgType, quickfix.FIXString(constants.MsgTypeStatus))
@@ -116,27 +147,146 @@ func BuildStatus(clId, ordId, side, symbol string, config *constants.Config) *qu
 	return m
 }
 
+// BuildCancelOrder validates req and builds the Order Cancel Request for it.
+func BuildCancelOrder(req CancelRequest, config *constants.Config) (*quickfix.Message, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return cancelMessage(req, config), nil
+}
+
+// BuildCancel builds an Order Cancel Request for a cached order.
+//
+// Deprecated: use BuildCancelOrder with a CancelRequest.
 func BuildCancel(info model.OrderInfo, portfolio string, config *constants.Config) *quickfix.Message {
+	return cancelMessage(CancelRequest{
+		Portfolio:   portfolio,
+		OrigClOrdId: info.ClOrdId,
+		OrderId:     info.OrderId,
+		Symbol:      info.Symbol,
+		Side:        info.Side,
+		Qty:         info.Quantity,
+	}, config)
+}
+
+func cancelMessage(req CancelRequest, config *constants.Config) *quickfix.Message {
 	m := quickfix.NewMessage()
 	m.Header.SetField(constants.TagMsgType, quickfix.FIXString(constants.MsgTypeCancel))
 	m.Header.SetField(constants.TagSenderCompId, quickfix.FIXString(config.SenderCompId))
 	m.Header.SetField(constants.TagTargetCompId, quickfix.FIXString(config.TargetCompId))
 	m.Header.SetField(constants.TagSendingTime, quickfix.FIXString(time.Now().UTC().Format(constants.FixTimeFormat)))
 
+	side := fixSide(req.Side)
+	if side == "" {
+		side = req.Side
+	}
 	cancelClId := fmt.Sprintf("cancel-%d", time.Now().UnixNano())
-	m.Body.SetField(constants.TagAccount, quickfix.FIXString(portfolio))
+	m.Body.SetField(constants.TagAccount, quickfix.FIXString(req.Portfolio))
 	m.Body.SetField(constants.TagClOrdId, quickfix.FIXString(cancelClId))
-	m.Body.SetField(constants.TagOrigClOrdId, quickfix.FIXString(info.ClOrdId))
+	m.Body.SetField(constants.TagOrigClOrdId, quickfix.FIXString(req.OrigClOrdId))
+	m.Body.SetField(constants.TagOrderId, quickfix.FIXString(req.OrderId))
+	m.Body.SetField(constants.TagOrderQty, quickfix.FIXString(normalize(req.Qty)))
+	m.Body.SetField(constants.TagSide, quickfix.FIXString(side))
+	m.Body.SetField(constants.TagSymbol, quickfix.FIXString(req.Symbol))
+	return m
+}
+
+// BuildCancelReplace amends a resting LIMIT or VWAP order. info carries the
+// amended order with OrigClOrdId naming the order being replaced; the new
+// ClOrdID is generated here.
+func BuildCancelReplace(info model.OrderInfo, portfolio string, config *constants.Config) (*quickfix.Message, error) {
+	m := quickfix.NewMessage()
+	m.Header.SetField(constants.TagMsgType, quickfix.FIXString(constants.MsgTypeReplace))
+	m.Header.SetField(constants.TagSenderCompId, quickfix.FIXString(config.SenderCompId))
+	m.Header.SetField(constants.TagTargetCompId, quickfix.FIXString(config.TargetCompId))
+	m.Header.SetField(constants.TagSendingTime, quickfix.FIXString(time.Now().UTC().Format(constants.FixTimeFormat)))
+
+	if info.OrigClOrdId == "" {
+		return nil, fmt.Errorf("original ClOrdId is required")
+	}
+	if err := normalizeAmounts(&info); err != nil {
+		return nil, err
+	}
+
+	clId := fmt.Sprintf("%d", time.Now().UnixNano())
+	m.Body.SetField(constants.TagAccount, quickfix.FIXString(portfolio))
+	m.Body.SetField(constants.TagClOrdId, quickfix.FIXString(clId))
+	m.Body.SetField(constants.TagOrigClOrdId, quickfix.FIXString(info.OrigClOrdId))
 	m.Body.SetField(constants.TagOrderId, quickfix.FIXString(info.OrderId))
-	m.Body.SetField(constants.TagOrderQty, quickfix.FIXString(info.Quantity))
 	m.Body.SetField(constants.TagSide, quickfix.FIXString(info.Side))
 	m.Body.SetField(constants.TagSymbol, quickfix.FIXString(info.Symbol))
-	return m
+
+	if strings.EqualFold(info.QtyType, "QUOTE") {
+		m.Body.SetField(constants.TagCashOrderQty, quickfix.FIXString(info.Quantity))
+	} else {
+		m.Body.SetField(constants.TagOrderQty, quickfix.FIXString(info.Quantity))
+	}
+
+	if strings.EqualFold(info.OrdType, constants.OrdTypeLimit) {
+		if info.TimeInForce != "" {
+			if err := validateTimeInForce(info.TimeInForce, info.ExpireTime); err != nil {
+				return nil, err
+			}
+		}
+		m.Body.SetField(constants.TagOrdType, quickfix.FIXString(constants.OrdTypeLimitFix))
+		m.Body.SetField(constants.TagTimeInForce, quickfix.FIXString(timeInForceCode(info.TimeInForce, info.ExpireTime)))
+		m.Body.SetField(constants.TagTargetStrategy, quickfix.FIXString(constants.TargetStrategyLimit))
+	} else if strings.EqualFold(info.OrdType, constants.OrdTypeVwap) {
+		if info.ExpireTime == "" {
+			return nil, fmt.Errorf("expire time is required for VWAP orders")
+		}
+		m.Body.SetField(constants.TagOrdType, quickfix.FIXString(constants.OrdTypeVwapFix))
+		m.Body.SetField(constants.TagTimeInForce, quickfix.FIXString(constants.TimeInForceGtd))
+		m.Body.SetField(constants.TagTargetStrategy, quickfix.FIXString(constants.TargetStrategyVwap))
+		if info.StartTime != "" {
+			m.Body.SetField(constants.TagStartTime, quickfix.FIXString(fixTime(info.StartTime)))
+		}
+		if info.ParticipationRate != "" {
+			m.Body.SetField(constants.TagParticipationRate, quickfix.FIXString(info.ParticipationRate))
+		}
+	} else if strings.EqualFold(info.OrdType, constants.OrdTypeTwap) {
+		if info.ExpireTime == "" {
+			return nil, fmt.Errorf("expire time is required for TWAP orders")
+		}
+		setTwapOrdType(m, info.LimitPrice)
+		m.Body.SetField(constants.TagTimeInForce, quickfix.FIXString(constants.TimeInForceGtd))
+		m.Body.SetField(constants.TagTargetStrategy, quickfix.FIXString(constants.TargetStrategyTwap))
+		if info.StartTime != "" {
+			m.Body.SetField(constants.TagStartTime, quickfix.FIXString(fixTime(info.StartTime)))
+		}
+	} else if strings.EqualFold(info.OrdType, constants.OrdTypeStopLimit) {
+		if err := ValidateStopLimit(sideName(info.Side), info.LimitPrice, info.StopPx); err != nil {
+			return nil, err
+		}
+		m.Body.SetField(constants.TagOrdType, quickfix.FIXString(constants.OrdTypeStopLimitFix))
+		m.Body.SetField(constants.TagTimeInForce, quickfix.FIXString(constants.TimeInForceGtc))
+		m.Body.SetField(constants.TagStopPx, quickfix.FIXString(info.StopPx))
+		m.Body.SetField(constants.TagTargetStrategy, quickfix.FIXString(constants.TargetStrategyStopLimit))
+	} else {
+		return nil, fmt.Errorf("cannot replace %s orders (only LIMIT, STOP_LIMIT, VWAP and TWAP)", info.OrdType)
+	}
+
+	if info.LimitPrice != "" {
+		m.Body.SetField(constants.TagPx, quickfix.FIXString(info.LimitPrice))
+	}
+	if err := SetExecInst(m, info.ExecInst); err != nil {
+		return nil, err
+	}
+	if info.ExpireTime != "" {
+		m.Body.SetField(constants.TagExpireTime, quickfix.FIXString(fixTime(info.ExpireTime)))
+	}
+
+	return m, nil
 }
 
-func BuildQuoteRequest(
-	symbol, side, qtyType, qty, price, portfolio string, config *constants.Config,
-) (*quickfix.Message, error) {
+// BuildRfq validates req and builds the Quote Request for it. The generated
+// QuoteReqID is carried in QuoteReqID(131).
+func BuildRfq(req QuoteRequest, config *constants.Config) (*quickfix.Message, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	req = req.Normalize()
+
 	m := quickfix.NewMessage()
 	m.Header.SetField(constants.TagMsgType, quickfix.FIXString(constants.MsgTypeQuoteReq))
 	m.Header.SetField(constants.TagSenderCompId, quickfix.FIXString(config.SenderCompId))
@@ -145,31 +295,40 @@ func BuildQuoteRequest(
 
 	quoteReqId := fmt.Sprintf("qr-%d", time.Now().UnixNano())
 	m.Body.SetField(constants.TagQuoteReqId, quickfix.FIXString(quoteReqId))
-	m.Body.SetField(constants.TagAccount, quickfix.FIXString(portfolio))
-	m.Body.SetField(constants.TagSymbol, quickfix.FIXString(symbol))
+	m.Body.SetField(constants.TagAccount, quickfix.FIXString(req.Portfolio))
+	m.Body.SetField(constants.TagSymbol, quickfix.FIXString(req.Symbol))
 
 	// Set quantity based on user preference (BASE or QUOTE)
-	if strings.EqualFold(qtyType, "BASE") {
-		m.Body.SetField(constants.TagOrderQty, quickfix.FIXString(qty))
-	} else if strings.EqualFold(qtyType, "QUOTE") {
-		m.Body.SetField(constants.TagCashOrderQty, quickfix.FIXString(qty))
+	if strings.EqualFold(req.QtyType, "BASE") {
+		m.Body.SetField(constants.TagOrderQty, quickfix.FIXString(req.Qty))
 	} else {
-		return nil, fmt.Errorf("invalid quantity type: %s (must be BASE or QUOTE)", qtyType)
+		m.Body.SetField(constants.TagCashOrderQty, quickfix.FIXString(req.Qty))
 	}
 
 	m.Body.SetField(constants.TagOrdType, quickfix.FIXString(constants.OrdTypeLimitFix))
-	m.Body.SetField(constants.TagPx, quickfix.FIXString(price))
+	m.Body.SetField(constants.TagPx, quickfix.FIXString(req.Price))
 	m.Body.SetField(constants.TagTimeInForce, quickfix.FIXString(constants.TimeInForceFok))
-
-	if strings.EqualFold(side, constants.SideBuy) {
-		m.Body.SetField(constants.TagSide, quickfix.FIXString(constants.SideBuyFix))
-	} else {
-		m.Body.SetField(constants.TagSide, quickfix.FIXString(constants.SideSellFix))
-	}
+	m.Body.SetField(constants.TagSide, quickfix.FIXString(fixSide(req.Side)))
 
 	return m, nil
 }
 
+// BuildQuoteRequest builds a Quote Request from positional arguments.
+//
+// Deprecated: use BuildRfq with a QuoteRequest.
+func BuildQuoteRequest(
+	symbol, side, qtyType, qty, price, portfolio string, config *constants.Config,
+) (*quickfix.Message, error) {
+	return BuildRfq(QuoteRequest{
+		Portfolio: portfolio,
+		Symbol:    symbol,
+		Side:      side,
+		QtyType:   qtyType,
+		Qty:       qty,
+		Price:     price,
+	}, config)
+}
+
 func BuildAcceptQuote(
 	quoteId, symbol, side, qty, price, portfolio string, config *constants.Config,
 ) *quickfix.Message {
@@ -201,9 +360,11 @@ func BuildAcceptQuote(
 
 func BuildLogon(
 	body *quickfix.Body,
-	ts, apiKey, apiSecret, passphrase, targetCompId, portfolioId string,
+	ts, seqNum, apiKey string,
+	apiSecret []byte,
+	passphrase, targetCompId, portfolioId string,
 ) {
-	sig := utils.Sign(ts, "A", "1", apiKey, targetCompId, passphrase, apiSecret)
+	sig := utils.Sign(ts, "A", seqNum, apiKey, targetCompId, passphrase, apiSecret)
 
 	body.SetField(constants.TagAccount, quickfix.FIXString(portfolioId))
 	body.SetField(constants.TagHmac, quickfix.FIXString(sig))
@@ -211,3 +372,202 @@ func BuildLogon(
 	body.SetField(constants.TagDropCopyFlag, quickfix.FIXString("Y"))
 	body.SetField(constants.TagAccessKey, quickfix.FIXString(apiKey))
 }
+
+// timeInForceCodes maps the time-in-force names accepted for LIMIT orders to
+// TimeInForce(59) values.
+var timeInForceCodes = map[string]string{
+	"GTC": constants.TimeInForceGtc,
+	"GTD": constants.TimeInForceGtd,
+	"IOC": constants.TimeInForceIoc,
+	"FOK": constants.TimeInForceFok,
+}
+
+// ValidateTimeInForce checks the time in force of a new LIMIT order. An empty
+// tif keeps the default; GTD requires an expire time in the future, which no
+// other time in force accepts.
+func ValidateTimeInForce(tif, expireTime string) error {
+	if err := validateTimeInForce(tif, expireTime); err != nil {
+		return err
+	}
+	if expireTime == "" {
+		return nil
+	}
+	expire, _ := time.Parse(time.RFC3339, expireTime)
+	if !expire.After(time.Now()) {
+		return fmt.Errorf("expire time %s is in the past", expireTime)
+	}
+	return nil
+}
+
+// validateTimeInForce checks tif and expireTime against each other without
+// requiring the expire time to be in the future, so that resting GTD orders
+// can still be replaced.
+func validateTimeInForce(tif, expireTime string) error {
+	tif = strings.ToUpper(tif)
+	if _, ok := timeInForceCodes[tif]; !ok && tif != "" {
+		return fmt.Errorf("invalid time in force %q (GTC, GTD, IOC or FOK)", tif)
+	}
+	if tif == "GTD" && expireTime == "" {
+		return fmt.Errorf("an expire time is required for GTD orders")
+	}
+	if expireTime == "" {
+		return nil
+	}
+	if tif != "GTD" && tif != "" {
+		return fmt.Errorf("an expire time is only allowed for GTD orders")
+	}
+	if _, err := time.Parse(time.RFC3339, expireTime); err != nil {
+		return fmt.Errorf("expire time must be ISO 8601, e.g. 2025-08-01T16:00:00Z")
+	}
+	return nil
+}
+
+// timeInForceCode returns the TimeInForce(59) value for a LIMIT order. With
+// no tif, an order with an expire time is GTD and any other is GTC.
+func timeInForceCode(tif, expireTime string) string {
+	if code, ok := timeInForceCodes[strings.ToUpper(tif)]; ok {
+		return code
+	}
+	if expireTime != "" {
+		return constants.TimeInForceGtd
+	}
+	return constants.TimeInForceGtc
+}
+
+// execInstNames maps readable ExecInst names to their ExecInst(18) values.
+var execInstNames = map[string]string{
+	"POST_ONLY": constants.ExecInstPostOnly,
+}
+
+// ParseExecInst turns a comma-separated list of ExecInst names or single
+// character codes into the space-separated ExecInst(18) value.
+func ParseExecInst(list string) (string, error) {
+	var codes []string
+	for _, item := range strings.Split(list, ",") {
+		item = strings.ToUpper(strings.TrimSpace(item))
+		if code, ok := execInstNames[item]; ok {
+			item = code
+		}
+		if len(item) != 1 {
+			return "", fmt.Errorf("invalid ExecInst %q (POST_ONLY or a single-character code)", item)
+		}
+		codes = append(codes, item)
+	}
+	return strings.Join(codes, " "), nil
+}
+
+// SetExecInst sets ExecInst(18) on a built order. Post-only is only accepted
+// on LIMIT orders that can rest on the book, i.e. not IOC or FOK.
+func SetExecInst(m *quickfix.Message, execInst string) error {
+	if execInst == "" {
+		return nil
+	}
+	if HasExecInst(execInst, constants.ExecInstPostOnly) {
+		ordType, _ := m.Body.GetString(constants.TagOrdType)
+		strategy, _ := m.Body.GetString(constants.TagTargetStrategy)
+		tif, _ := m.Body.GetString(constants.TagTimeInForce)
+		if ordType != constants.OrdTypeLimitFix || strategy != constants.TargetStrategyLimit {
+			return fmt.Errorf("post-only is only supported on LIMIT orders")
+		}
+		if tif == constants.TimeInForceIoc || tif == constants.TimeInForceFok {
+			return fmt.Errorf("post-only orders cannot be IOC or FOK")
+		}
+	}
+	m.Body.SetField(constants.TagExecInst, quickfix.FIXString(execInst))
+	return nil
+}
+
+// HasExecInst reports whether the space-separated ExecInst value contains code.
+func HasExecInst(execInst, code string) bool {
+	for _, c := range strings.Fields(execInst) {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateStopLimit checks that a stop-limit order has both prices and that
+// the stop triggers before the limit is reached: at or below the limit for a
+// BUY, at or above it for a SELL.
+func ValidateStopLimit(side, price, stopPx string) error {
+	limit, err := amount.Positive(price)
+	if err != nil {
+		return fmt.Errorf("a positive limit price is required for STOP_LIMIT orders")
+	}
+	stop, err := amount.Positive(stopPx)
+	if err != nil {
+		return fmt.Errorf("a positive stop price is required for STOP_LIMIT orders")
+	}
+	switch strings.ToUpper(side) {
+	case constants.SideBuy:
+		if stop.GreaterThan(limit) {
+			return fmt.Errorf("stop price %s must not be above limit price %s for a BUY", stopPx, price)
+		}
+	case constants.SideSell:
+		if stop.LessThan(limit) {
+			return fmt.Errorf("stop price %s must not be below limit price %s for a SELL", stopPx, price)
+		}
+	default:
+		return fmt.Errorf("side must be BUY or SELL")
+	}
+	return nil
+}
+
+// normalizeAmounts checks the quantity and prices of an order being replaced
+// and puts them in wire form.
+func normalizeAmounts(info *model.OrderInfo) error {
+	amounts := []struct {
+		name     string
+		value    *string
+		optional bool
+	}{
+		{"quantity", &info.Quantity, false},
+		{"price", &info.LimitPrice, true},
+		{"stop price", &info.StopPx, true},
+		{"participation rate", &info.ParticipationRate, true},
+	}
+	for _, a := range amounts {
+		if *a.value == "" && a.optional {
+			continue
+		}
+		wire, err := amount.Normalize(*a.value)
+		if err != nil {
+			return fmt.Errorf("%s %q %v", a.name, *a.value, err)
+		}
+		*a.value = wire
+	}
+	return nil
+}
+
+// sideName maps a FIX Side(54) code to BUY or SELL, passing anything else through.
+func sideName(side string) string {
+	switch side {
+	case constants.SideBuyFix:
+		return constants.SideBuy
+	case constants.SideSellFix:
+		return constants.SideSell
+	}
+	return side
+}
+
+// setTwapOrdType makes a TWAP order a limit order when it has a price and a
+// market order otherwise.
+func setTwapOrdType(m *quickfix.Message, price string) {
+	if price == "" {
+		m.Body.SetField(constants.TagOrdType, quickfix.FIXString(constants.OrdTypeMarketFix))
+		return
+	}
+	m.Body.SetField(constants.TagOrdType, quickfix.FIXString(constants.OrdTypeLimitFix))
+	m.Body.SetField(constants.TagPx, quickfix.FIXString(price))
+}
+
+// fixTime converts an ISO 8601 UTC time to FIX UTCTimestamp format, passing
+// through values that are already in another format.
+func fixTime(value string) string {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+	return t.UTC().Format(constants.FixTimeFormat)
+}
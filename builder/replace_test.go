@@ -0,0 +1,81 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package builder
+
+import (
+	"os"
+	"testing"
+
+	"prime-fix-go/constants"
+	"prime-fix-go/model"
+)
+
+func TestBuildCancelReplace(t *testing.T) {
+	os.Setenv("SVC_ACCOUNT_ID", "test-sender")
+	os.Setenv("TARGET_COMP_ID", "COIN")
+
+	config := constants.NewConfig()
+	info := model.OrderInfo{
+		OrigClOrdId: "orig123",
+		OrderId:     "order123",
+		Side:        constants.SideBuyFix,
+		Symbol:      "BTC-USD",
+		Quantity:    "0.5",
+		LimitPrice:  "51000",
+		OrdType:     constants.OrdTypeLimit,
+		QtyType:     "BASE",
+	}
+	msg, err := BuildCancelReplace(info, "test-portfolio", config)
+
+	if err != nil {
+		t.Fatalf("BuildCancelReplace returned error: %v", err)
+	}
+
+	msgType, _ := msg.Header.GetString(constants.TagMsgType)
+	if msgType != constants.MsgTypeReplace {
+		t.Errorf("Expected message type %s, got %s", constants.MsgTypeReplace, msgType)
+	}
+
+	origClOrdId, _ := msg.Body.GetString(constants.TagOrigClOrdId)
+	if origClOrdId != "orig123" {
+		t.Errorf("Expected OrigClOrdID orig123, got %s", origClOrdId)
+	}
+
+	clOrdId, _ := msg.Body.GetString(constants.TagClOrdId)
+	if clOrdId == "" || clOrdId == "orig123" {
+		t.Errorf("Expected a new ClOrdID, got %q", clOrdId)
+	}
+
+	price, _ := msg.Body.GetString(constants.TagPx)
+	if price != "51000" {
+		t.Errorf("Expected price 51000, got %s", price)
+	}
+
+	qty, _ := msg.Body.GetString(constants.TagOrderQty)
+	if qty != "0.5" {
+		t.Errorf("Expected quantity 0.5, got %s", qty)
+	}
+}
+
+func TestBuildCancelReplaceRejectsMarket(t *testing.T) {
+	config := constants.NewConfig()
+	info := model.OrderInfo{OrigClOrdId: "orig123", OrdType: constants.OrdTypeMarket}
+
+	if _, err := BuildCancelReplace(info, "test-portfolio", config); err == nil {
+		t.Error("Expected error replacing a MARKET order")
+	}
+}
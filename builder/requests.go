@@ -0,0 +1,354 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package builder
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"prime-fix-go/amount"
+	"prime-fix-go/constants"
+)
+
+// Request field names reported in a FieldError.
+const (
+	FieldSymbol            = "symbol"
+	FieldOrdType           = "ordType"
+	FieldSide              = "side"
+	FieldQtyType           = "qtyType"
+	FieldQty               = "qty"
+	FieldPrice             = "price"
+	FieldStopPx            = "stopPx"
+	FieldTimeInForce       = "timeInForce"
+	FieldStartTime         = "startTime"
+	FieldExpireTime        = "expireTime"
+	FieldParticipationRate = "participationRate"
+	FieldExecInst          = "execInst"
+	FieldOrigClOrdId       = "origClOrdId"
+)
+
+// FieldError describes one invalid field of a request.
+type FieldError struct {
+	Field  string `json:"field"`
+	Value  string `json:"value,omitempty"`
+	Reason string `json:"reason"`
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Reason
+}
+
+// ValidationError lists every invalid field of a request. errors.As finds the
+// individual *FieldError values through Unwrap.
+type ValidationError struct {
+	Fields []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Fields))
+	for i, f := range e.Fields {
+		errs[i] = f
+	}
+	return errs
+}
+
+// validation accumulates field errors while a request is checked.
+type validation struct {
+	fields []*FieldError
+}
+
+func (v *validation) add(field, value, format string, args ...any) {
+	v.fields = append(v.fields, &FieldError{Field: field, Value: value, Reason: fmt.Sprintf(format, args...)})
+}
+
+func (v *validation) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: v.fields}
+}
+
+func (v *validation) required(field, value string) bool {
+	if strings.TrimSpace(value) == "" {
+		v.add(field, value, "is required")
+		return false
+	}
+	return true
+}
+
+func (v *validation) side(value string) {
+	if !strings.EqualFold(value, constants.SideBuy) && !strings.EqualFold(value, constants.SideSell) {
+		v.add(FieldSide, value, "must be BUY or SELL")
+	}
+}
+
+func (v *validation) qtyType(value string) {
+	if !strings.EqualFold(value, "BASE") && !strings.EqualFold(value, "QUOTE") {
+		v.add(FieldQtyType, value, "must be BASE or QUOTE")
+	}
+}
+
+// positive checks that value is a positive decimal and returns it.
+func (v *validation) positive(field, value string) (decimal.Decimal, bool) {
+	d, err := amount.Positive(value)
+	if err != nil {
+		v.add(field, value, "%v", err)
+		return d, false
+	}
+	return d, true
+}
+
+// timestamp checks that value is an ISO 8601 time and returns it.
+func (v *validation) timestamp(field, value string) (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		v.add(field, value, "must be ISO 8601, e.g. 2025-08-01T16:00:00Z")
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// NewOrderRequest describes a New Order Single. Which fields apply depends on
+// OrdType:
+//
+//	MARKET      no price
+//	LIMIT       Price; optional TimeInForce (GTC, GTD, IOC, FOK), ExpireTime for GTD, ExecInst
+//	STOP_LIMIT  Price and StopPx
+//	VWAP        Price and ExpireTime; optional StartTime and ParticipationRate
+//	TWAP        StartTime and ExpireTime; optional Price
+type NewOrderRequest struct {
+	Portfolio         string `json:"portfolio,omitempty"`
+	Symbol            string `json:"symbol,omitempty"`
+	OrdType           string `json:"ordType,omitempty"`
+	Side              string `json:"side,omitempty"`
+	QtyType           string `json:"qtyType,omitempty"`
+	Qty               string `json:"qty,omitempty"`
+	Price             string `json:"price,omitempty"`
+	StopPx            string `json:"stopPx,omitempty"`
+	TimeInForce       string `json:"timeInForce,omitempty"`
+	StartTime         string `json:"startTime,omitempty"`
+	ExpireTime        string `json:"expireTime,omitempty"`
+	ParticipationRate string `json:"participationRate,omitempty"`
+	// ExecInst is the space-separated ExecInst(18) value; see ParseExecInst.
+	ExecInst string `json:"execInst,omitempty"`
+}
+
+// Validate reports every invalid field of the request as a *ValidationError.
+func (r NewOrderRequest) Validate() error {
+	v := &validation{}
+	v.required(FieldSymbol, r.Symbol)
+	v.side(r.Side)
+	v.qtyType(r.QtyType)
+	v.positive(FieldQty, r.Qty)
+
+	ordType := strings.ToUpper(r.OrdType)
+	switch ordType {
+	case constants.OrdTypeMarket:
+		if r.Price != "" {
+			v.add(FieldPrice, r.Price, "is not allowed on MARKET orders")
+		}
+	case constants.OrdTypeLimit:
+		if v.required(FieldPrice, r.Price) {
+			v.positive(FieldPrice, r.Price)
+		}
+		if err := validateTimeInForce(r.TimeInForce, r.ExpireTime); err != nil {
+			field, value := FieldTimeInForce, r.TimeInForce
+			if r.ExpireTime != "" || strings.EqualFold(r.TimeInForce, "GTD") {
+				field, value = FieldExpireTime, r.ExpireTime
+			}
+			v.add(field, value, "%v", err)
+		} else if r.ExpireTime != "" {
+			v.future(FieldExpireTime, r.ExpireTime)
+		}
+	case constants.OrdTypeStopLimit:
+		var limit decimal.Decimal
+		limitOk := false
+		if v.required(FieldPrice, r.Price) {
+			limit, limitOk = v.positive(FieldPrice, r.Price)
+		}
+		if v.required(FieldStopPx, r.StopPx) {
+			if stop, ok := v.positive(FieldStopPx, r.StopPx); ok && limitOk {
+				if strings.EqualFold(r.Side, constants.SideBuy) && stop.GreaterThan(limit) {
+					v.add(FieldStopPx, r.StopPx, "must not be above the limit price %s for a BUY", r.Price)
+				}
+				if strings.EqualFold(r.Side, constants.SideSell) && stop.LessThan(limit) {
+					v.add(FieldStopPx, r.StopPx, "must not be below the limit price %s for a SELL", r.Price)
+				}
+			}
+		}
+	case constants.OrdTypeVwap:
+		if v.required(FieldPrice, r.Price) {
+			v.positive(FieldPrice, r.Price)
+		}
+		if r.ParticipationRate != "" {
+			if rate, ok := v.positive(FieldParticipationRate, r.ParticipationRate); ok && rate.GreaterThan(decimal.NewFromInt(1)) {
+				v.add(FieldParticipationRate, r.ParticipationRate, "must be at most 1")
+			}
+		}
+		v.schedule(r.StartTime, r.ExpireTime, false)
+	case constants.OrdTypeTwap:
+		if r.Price != "" {
+			v.positive(FieldPrice, r.Price)
+		}
+		v.schedule(r.StartTime, r.ExpireTime, true)
+	default:
+		v.add(FieldOrdType, r.OrdType, "must be MARKET, LIMIT, STOP_LIMIT, VWAP or TWAP")
+	}
+
+	if r.ExecInst != "" {
+		if HasExecInst(r.ExecInst, constants.ExecInstPostOnly) {
+			tif := strings.ToUpper(r.TimeInForce)
+			if ordType != constants.OrdTypeLimit {
+				v.add(FieldExecInst, r.ExecInst, "post-only is only supported on LIMIT orders")
+			} else if tif == "IOC" || tif == "FOK" {
+				v.add(FieldExecInst, r.ExecInst, "post-only orders cannot be IOC or FOK")
+			}
+		}
+		for _, code := range strings.Fields(r.ExecInst) {
+			if len(code) != 1 {
+				v.add(FieldExecInst, r.ExecInst, "must be space-separated single-character codes")
+				break
+			}
+		}
+	}
+	return v.err()
+}
+
+// Normalize returns a copy of the request with its quantities and prices in
+// wire form. Values that do not parse are left for Validate to report.
+func (r NewOrderRequest) Normalize() NewOrderRequest {
+	r.Qty = normalize(r.Qty)
+	r.Price = normalize(r.Price)
+	r.StopPx = normalize(r.StopPx)
+	r.ParticipationRate = normalize(r.ParticipationRate)
+	return r
+}
+
+// schedule checks the start and expire times of an algorithmic order. The
+// expire time is always required and must be in the future and after the
+// start time.
+func (v *validation) schedule(startTime, expireTime string, startRequired bool) {
+	var start time.Time
+	startOk := false
+	if startTime != "" || startRequired {
+		if v.required(FieldStartTime, startTime) {
+			start, startOk = v.timestamp(FieldStartTime, startTime)
+		}
+	}
+	if !v.required(FieldExpireTime, expireTime) {
+		return
+	}
+	expire, ok := v.future(FieldExpireTime, expireTime)
+	if ok && startOk && !expire.After(start) {
+		v.add(FieldExpireTime, expireTime, "must be after the start time")
+	}
+}
+
+// future checks that value is an ISO 8601 time in the future.
+func (v *validation) future(field, value string) (time.Time, bool) {
+	t, ok := v.timestamp(field, value)
+	if ok && !t.After(time.Now()) {
+		v.add(field, value, "is in the past")
+		return t, false
+	}
+	return t, ok
+}
+
+// QuoteRequest describes an RFQ. Price is the limit price the quote is
+// checked against.
+type QuoteRequest struct {
+	Portfolio string `json:"portfolio,omitempty"`
+	Symbol    string `json:"symbol,omitempty"`
+	Side      string `json:"side,omitempty"`
+	QtyType   string `json:"qtyType,omitempty"`
+	Qty       string `json:"qty,omitempty"`
+	Price     string `json:"price,omitempty"`
+}
+
+// Validate reports every invalid field of the request as a *ValidationError.
+func (r QuoteRequest) Validate() error {
+	v := &validation{}
+	v.required(FieldSymbol, r.Symbol)
+	v.side(r.Side)
+	v.qtyType(r.QtyType)
+	v.positive(FieldQty, r.Qty)
+	if v.required(FieldPrice, r.Price) {
+		v.positive(FieldPrice, r.Price)
+	}
+	return v.err()
+}
+
+// Normalize returns a copy of the request with its quantity and price in
+// wire form.
+func (r QuoteRequest) Normalize() QuoteRequest {
+	r.Qty = normalize(r.Qty)
+	r.Price = normalize(r.Price)
+	return r
+}
+
+// CancelRequest describes an Order Cancel Request for the order named by
+// OrigClOrdId. Side may be BUY/SELL or the FIX Side(54) code.
+type CancelRequest struct {
+	Portfolio   string
+	OrigClOrdId string
+	OrderId     string
+	Symbol      string
+	Side        string
+	Qty         string
+}
+
+// Validate reports every invalid field of the request as a *ValidationError.
+func (r CancelRequest) Validate() error {
+	v := &validation{}
+	v.required(FieldOrigClOrdId, r.OrigClOrdId)
+	v.required(FieldSymbol, r.Symbol)
+	if fixSide(r.Side) == "" {
+		v.add(FieldSide, r.Side, "must be BUY or SELL")
+	}
+	return v.err()
+}
+
+// normalize returns the wire form of a positive decimal, or value unchanged
+// when it is empty or invalid.
+func normalize(value string) string {
+	if wire, err := amount.Normalize(value); err == nil {
+		return wire
+	}
+	return value
+}
+
+// fixSide maps BUY/SELL or a FIX Side(54) code to the FIX code, or "".
+func fixSide(side string) string {
+	switch strings.ToUpper(side) {
+	case constants.SideBuy, constants.SideBuyFix:
+		return constants.SideBuyFix
+	case constants.SideSell, constants.SideSellFix:
+		return constants.SideSellFix
+	}
+	return ""
+}
@@ -0,0 +1,250 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package builder
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/quickfixgo/quickfix"
+
+	"prime-fix-go/constants"
+)
+
+func TestNewOrderRequestValidateReportsEveryField(t *testing.T) {
+	req := NewOrderRequest{
+		Symbol:  "BTC-USD",
+		OrdType: "LIMIT",
+		Side:    "HOLD",
+		QtyType: "BASE",
+		Qty:     "-1",
+	}
+
+	err := req.Validate()
+	var invalid *ValidationError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("Expected *ValidationError, got %v", err)
+	}
+
+	got := make(map[string]string)
+	for _, f := range invalid.Fields {
+		got[f.Field] = f.Reason
+	}
+	want := map[string]string{
+		FieldSide:  "must be BUY or SELL",
+		FieldQty:   "must be greater than zero",
+		FieldPrice: "is required",
+	}
+	for field, reason := range want {
+		if got[field] != reason {
+			t.Errorf("Expected %s %q, got %q", field, reason, got[field])
+		}
+	}
+	if len(invalid.Fields) != len(want) {
+		t.Errorf("Expected %d field errors, got %v", len(want), err)
+	}
+}
+
+func TestNewOrderRequestValidateFieldError(t *testing.T) {
+	req := NewOrderRequest{
+		Symbol:  "BTC-USD",
+		OrdType: "MARKET",
+		Side:    "BUY",
+		QtyType: "QUOTE",
+		Qty:     "abc",
+	}
+
+	var field *FieldError
+	if !errors.As(req.Validate(), &field) {
+		t.Fatal("Expected errors.As to find a *FieldError")
+	}
+	if field.Field != FieldQty || field.Value != "abc" {
+		t.Errorf("Expected qty error for abc, got %+v", field)
+	}
+}
+
+func TestNewOrderRequestValidateReportsExpireTimeValue(t *testing.T) {
+	for _, expire := range []string{"tomorrow", "2020-01-01T00:00:00Z"} {
+		req := NewOrderRequest{
+			Symbol:      "BTC-USD",
+			OrdType:     "LIMIT",
+			Side:        "BUY",
+			QtyType:     "BASE",
+			Qty:         "1",
+			Price:       "50000",
+			TimeInForce: "GTD",
+			ExpireTime:  expire,
+		}
+
+		var invalid *ValidationError
+		if !errors.As(req.Validate(), &invalid) || len(invalid.Fields) != 1 {
+			t.Fatalf("Expected one field error for expire time %q, got %v", expire, invalid)
+		}
+		if f := invalid.Fields[0]; f.Field != FieldExpireTime || f.Value != expire {
+			t.Errorf("Expected %s=%q, got %s=%q", FieldExpireTime, expire, f.Field, f.Value)
+		}
+	}
+}
+
+func TestNewOrderRequestValidateByOrdType(t *testing.T) {
+	base := NewOrderRequest{Symbol: "BTC-USD", Side: "BUY", QtyType: "BASE", Qty: "1"}
+	tests := []struct {
+		name  string
+		edit  func(r *NewOrderRequest)
+		field string
+	}{
+		{"market", func(r *NewOrderRequest) { r.OrdType = "MARKET" }, ""},
+		{"market with price", func(r *NewOrderRequest) { r.OrdType = "MARKET"; r.Price = "1" }, FieldPrice},
+		{"limit gtd without expire", func(r *NewOrderRequest) { r.OrdType = "LIMIT"; r.Price = "1"; r.TimeInForce = "GTD" }, FieldExpireTime},
+		{"stop above buy limit", func(r *NewOrderRequest) { r.OrdType = "STOP_LIMIT"; r.Price = "100"; r.StopPx = "101" }, FieldStopPx},
+		{"vwap without expire", func(r *NewOrderRequest) { r.OrdType = "VWAP"; r.Price = "1" }, FieldExpireTime},
+		{"vwap rate above one", func(r *NewOrderRequest) {
+			r.OrdType, r.Price, r.ParticipationRate, r.ExpireTime = "VWAP", "1", "1.5", "2099-01-01T00:00:00Z"
+		}, FieldParticipationRate},
+		{"twap without start", func(r *NewOrderRequest) { r.OrdType = "TWAP"; r.ExpireTime = "2099-01-01T00:00:00Z" }, FieldStartTime},
+		{"twap ends before start", func(r *NewOrderRequest) {
+			r.OrdType, r.StartTime, r.ExpireTime = "TWAP", "2099-01-02T00:00:00Z", "2099-01-01T00:00:00Z"
+		}, FieldExpireTime},
+		{"post-only market", func(r *NewOrderRequest) { r.OrdType = "MARKET"; r.ExecInst = constants.ExecInstPostOnly }, FieldExecInst},
+		{"unknown type", func(r *NewOrderRequest) { r.OrdType = "ICEBERG" }, FieldOrdType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := base
+			tt.edit(&req)
+			err := req.Validate()
+			if tt.field == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				return
+			}
+			var field *FieldError
+			if !errors.As(err, &field) || field.Field != tt.field {
+				t.Errorf("Expected %s error, got %v", tt.field, err)
+			}
+		})
+	}
+}
+
+func TestBuildNewOrderRejectsInvalidRequest(t *testing.T) {
+	msg, err := BuildNewOrder(NewOrderRequest{OrdType: "LIMIT"}, constants.NewConfig())
+	if msg != nil || err == nil {
+		t.Fatal("Expected BuildNewOrder to reject an empty request")
+	}
+}
+
+func TestBuildNewOrderLimit(t *testing.T) {
+	msg, err := BuildNewOrder(NewOrderRequest{
+		Portfolio:   "test-portfolio",
+		Symbol:      "ETH-USD",
+		OrdType:     "LIMIT",
+		Side:        "SELL",
+		QtyType:     "BASE",
+		Qty:         "3",
+		Price:       "2500",
+		TimeInForce: "GTC",
+		ExecInst:    constants.ExecInstPostOnly,
+	}, constants.NewConfig())
+	if err != nil {
+		t.Fatalf("BuildNewOrder returned error: %v", err)
+	}
+
+	fields := map[quickfix.Tag]string{
+		constants.TagAccount:        "test-portfolio",
+		constants.TagSide:           constants.SideSellFix,
+		constants.TagOrderQty:       "3",
+		constants.TagPx:             "2500",
+		constants.TagTimeInForce:    constants.TimeInForceGtc,
+		constants.TagExecInst:       constants.ExecInstPostOnly,
+		constants.TagTargetStrategy: constants.TargetStrategyLimit,
+	}
+	for tag, want := range fields {
+		got, _ := msg.Body.GetString(tag)
+		if got != want {
+			t.Errorf("Expected tag %d = %s, got %s", tag, want, got)
+		}
+	}
+}
+
+func TestQuoteRequestValidate(t *testing.T) {
+	err := QuoteRequest{Symbol: "BTC-USD", Side: "BUY", QtyType: "BASE", Qty: "1"}.Validate()
+	var field *FieldError
+	if !errors.As(err, &field) || field.Field != FieldPrice {
+		t.Errorf("Expected price error, got %v", err)
+	}
+}
+
+func TestBuildCancelOrder(t *testing.T) {
+	if _, err := BuildCancelOrder(CancelRequest{Symbol: "BTC-USD", Side: "BUY"}, constants.NewConfig()); err == nil {
+		t.Error("Expected an error without OrigClOrdId")
+	}
+
+	msg, err := BuildCancelOrder(CancelRequest{
+		OrigClOrdId: "123",
+		OrderId:     "ord-1",
+		Symbol:      "BTC-USD",
+		Side:        constants.SideSellFix,
+		Qty:         "1",
+	}, constants.NewConfig())
+	if err != nil {
+		t.Fatalf("BuildCancelOrder returned error: %v", err)
+	}
+	if orig, _ := msg.Body.GetString(constants.TagOrigClOrdId); orig != "123" {
+		t.Errorf("Expected OrigClOrdId 123, got %s", orig)
+	}
+	if side, _ := msg.Body.GetString(constants.TagSide); side != constants.SideSellFix {
+		t.Errorf("Expected side %s, got %s", constants.SideSellFix, side)
+	}
+}
+
+func TestNewOrderRequestRejectsInexactNumbers(t *testing.T) {
+	for _, qty := range []string{"1e3", "NaN", "-5", "0", "0.1000000000000000001"} {
+		req := NewOrderRequest{Symbol: "BTC-USD", OrdType: "MARKET", Side: "BUY", QtyType: "BASE", Qty: qty}
+		var field *FieldError
+		if !errors.As(req.Validate(), &field) || field.Field != FieldQty {
+			t.Errorf("Expected qty %q to be rejected", qty)
+		}
+	}
+}
+
+func TestBuildNewOrderNormalizesWireFormat(t *testing.T) {
+	msg, err := BuildNewOrder(NewOrderRequest{
+		Symbol:  "BTC-USD",
+		OrdType: "STOP_LIMIT",
+		Side:    "SELL",
+		QtyType: "BASE",
+		Qty:     "0.50",
+		Price:   "057000.00",
+		StopPx:  "58000.0",
+	}, constants.NewConfig())
+	if err != nil {
+		t.Fatalf("BuildNewOrder returned error: %v", err)
+	}
+
+	fields := map[quickfix.Tag]string{
+		constants.TagOrderQty: "0.5",
+		constants.TagPx:       "57000",
+		constants.TagStopPx:   "58000",
+	}
+	for tag, want := range fields {
+		if got, _ := msg.Body.GetString(tag); got != want {
+			t.Errorf("Expected tag %d = %s, got %s", tag, want, got)
+		}
+	}
+}
@@ -54,8 +54,8 @@ func TestBuildQuoteRequest(t *testing.T) {
 	}
 
 	qty, _ := msg.Body.GetString(constants.TagOrderQty)
-	if qty != "1.0" {
-		t.Errorf("Expected quantity 1.0, got %s", qty)
+	if qty != "1" {
+		t.Errorf("Expected quantity 1, got %s", qty)
 	}
 
 	price, _ := msg.Body.GetString(constants.TagPx)
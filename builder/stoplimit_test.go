@@ -0,0 +1,103 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package builder
+
+import (
+	"testing"
+
+	"prime-fix-go/constants"
+	"prime-fix-go/model"
+)
+
+func TestBuildNewStopLimit(t *testing.T) {
+	config := constants.NewConfig()
+	msg, err := BuildNew("BTC-USD", "STOP_LIMIT", "BUY", "BASE", "0.5", "61000", "test-portfolio", config, "60000")
+
+	if err != nil {
+		t.Fatalf("BuildNew returned error: %v", err)
+	}
+
+	ordType, _ := msg.Body.GetString(constants.TagOrdType)
+	if ordType != constants.OrdTypeStopLimitFix {
+		t.Errorf("Expected order type %s, got %s", constants.OrdTypeStopLimitFix, ordType)
+	}
+
+	targetStrategy, _ := msg.Body.GetString(constants.TagTargetStrategy)
+	if targetStrategy != constants.TargetStrategyStopLimit {
+		t.Errorf("Expected target strategy %s, got %s", constants.TargetStrategyStopLimit, targetStrategy)
+	}
+
+	price, _ := msg.Body.GetString(constants.TagPx)
+	if price != "61000" {
+		t.Errorf("Expected price 61000, got %s", price)
+	}
+
+	stopPx, _ := msg.Body.GetString(constants.TagStopPx)
+	if stopPx != "60000" {
+		t.Errorf("Expected stop price 60000, got %s", stopPx)
+	}
+}
+
+func TestValidateStopLimit(t *testing.T) {
+	tests := []struct {
+		side, price, stop string
+		valid             bool
+	}{
+		{"BUY", "61000", "60000", true},
+		{"BUY", "60000", "60000", true},
+		{"BUY", "59000", "60000", false},
+		{"SELL", "59000", "60000", true},
+		{"SELL", "61000", "60000", false},
+		{"SELL", "59000", "", false},
+		{"BUY", "", "60000", false},
+	}
+	for _, tt := range tests {
+		err := ValidateStopLimit(tt.side, tt.price, tt.stop)
+		if (err == nil) != tt.valid {
+			t.Errorf("ValidateStopLimit(%s, %q, %q): expected valid=%v, got %v", tt.side, tt.price, tt.stop, tt.valid, err)
+		}
+	}
+}
+
+func TestBuildCancelReplaceStopLimit(t *testing.T) {
+	config := constants.NewConfig()
+	info := model.OrderInfo{
+		OrigClOrdId: "111",
+		OrderId:     "order-1",
+		Side:        constants.SideSellFix,
+		Symbol:      "BTC-USD",
+		Quantity:    "1.0",
+		LimitPrice:  "58000",
+		StopPx:      "59000",
+		OrdType:     constants.OrdTypeStopLimit,
+		QtyType:     "BASE",
+	}
+	msg, err := BuildCancelReplace(info, "test-portfolio", config)
+	if err != nil {
+		t.Fatalf("BuildCancelReplace returned error: %v", err)
+	}
+
+	stopPx, _ := msg.Body.GetString(constants.TagStopPx)
+	if stopPx != "59000" {
+		t.Errorf("Expected stop price 59000, got %s", stopPx)
+	}
+
+	info.StopPx = "57000"
+	if _, err := BuildCancelReplace(info, "test-portfolio", config); err == nil {
+		t.Error("Expected error for a SELL stop below its limit")
+	}
+}
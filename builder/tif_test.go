@@ -0,0 +1,101 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package builder
+
+import (
+	"testing"
+	"time"
+
+	"prime-fix-go/constants"
+	"prime-fix-go/model"
+)
+
+func TestBuildNewLimitTimeInForce(t *testing.T) {
+	config := constants.NewConfig()
+	expire := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+
+	tests := []struct {
+		tif, expire, want string
+	}{
+		{"", "", constants.TimeInForceGtc},
+		{"GTC", "", constants.TimeInForceGtc},
+		{"IOC", "", constants.TimeInForceIoc},
+		{"fok", "", constants.TimeInForceFok},
+		{"GTD", expire, constants.TimeInForceGtd},
+	}
+	for _, tt := range tests {
+		msg, err := BuildNew("BTC-USD", "LIMIT", "BUY", "BASE", "0.1", "30000", "test-portfolio", config, tt.tif, tt.expire)
+		if err != nil {
+			t.Fatalf("BuildNew(tif=%q) returned error: %v", tt.tif, err)
+		}
+
+		tif, _ := msg.Body.GetString(constants.TagTimeInForce)
+		if tif != tt.want {
+			t.Errorf("tif=%q: expected time in force %s, got %s", tt.tif, tt.want, tif)
+		}
+
+		if hasExpire := msg.Body.Has(constants.TagExpireTime); hasExpire != (tt.expire != "") {
+			t.Errorf("tif=%q: expected ExpireTime present=%v", tt.tif, tt.expire != "")
+		}
+	}
+}
+
+func TestValidateTimeInForce(t *testing.T) {
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+
+	tests := []struct {
+		tif, expire string
+		valid       bool
+	}{
+		{"GTD", future, true},
+		{"GTD", "", false},
+		{"GTD", past, false},
+		{"GTD", "tomorrow", false},
+		{"GTC", future, false},
+		{"DAY", "", false},
+	}
+	for _, tt := range tests {
+		err := ValidateTimeInForce(tt.tif, tt.expire)
+		if (err == nil) != tt.valid {
+			t.Errorf("ValidateTimeInForce(%q, %q): expected valid=%v, got %v", tt.tif, tt.expire, tt.valid, err)
+		}
+	}
+}
+
+func TestBuildCancelReplaceKeepsTimeInForce(t *testing.T) {
+	config := constants.NewConfig()
+	info := model.OrderInfo{
+		OrigClOrdId: "orig123",
+		Side:        constants.SideSellFix,
+		Symbol:      "BTC-USD",
+		Quantity:    "0.5",
+		LimitPrice:  "51000",
+		OrdType:     constants.OrdTypeLimit,
+		QtyType:     "BASE",
+		TimeInForce: "IOC",
+	}
+	msg, err := BuildCancelReplace(info, "test-portfolio", config)
+	if err != nil {
+		t.Fatalf("BuildCancelReplace returned error: %v", err)
+	}
+
+	tif, _ := msg.Body.GetString(constants.TagTimeInForce)
+	if tif != constants.TimeInForceIoc {
+		t.Errorf("Expected time in force %s, got %s", constants.TimeInForceIoc, tif)
+	}
+}
@@ -0,0 +1,129 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package builder
+
+import (
+	"os"
+	"testing"
+
+	"prime-fix-go/constants"
+	"prime-fix-go/model"
+)
+
+func TestBuildNewTwapLimit(t *testing.T) {
+	os.Setenv("SVC_ACCOUNT_ID", "test-sender")
+	os.Setenv("TARGET_COMP_ID", "COIN")
+
+	config := constants.NewConfig()
+	msg, err := BuildNew("BTC-USD", "TWAP", "BUY", "BASE", "2.0", "50000", "test-portfolio", config,
+		"2099-08-01T10:00:00Z", "2099-08-01T16:00:00Z")
+
+	if err != nil {
+		t.Fatalf("BuildNew returned error: %v", err)
+	}
+
+	ordType, _ := msg.Body.GetString(constants.TagOrdType)
+	if ordType != constants.OrdTypeLimitFix {
+		t.Errorf("Expected order type %s, got %s", constants.OrdTypeLimitFix, ordType)
+	}
+
+	targetStrategy, _ := msg.Body.GetString(constants.TagTargetStrategy)
+	if targetStrategy != constants.TargetStrategyTwap {
+		t.Errorf("Expected target strategy %s, got %s", constants.TargetStrategyTwap, targetStrategy)
+	}
+
+	tif, _ := msg.Body.GetString(constants.TagTimeInForce)
+	if tif != constants.TimeInForceGtd {
+		t.Errorf("Expected time in force %s, got %s", constants.TimeInForceGtd, tif)
+	}
+
+	price, _ := msg.Body.GetString(constants.TagPx)
+	if price != "50000" {
+		t.Errorf("Expected price 50000, got %s", price)
+	}
+
+	startTime, _ := msg.Body.GetString(constants.TagStartTime)
+	if startTime != "20990801-10:00:00.000" {
+		t.Errorf("Expected start time 20990801-10:00:00.000, got %s", startTime)
+	}
+
+	expireTime, _ := msg.Body.GetString(constants.TagExpireTime)
+	if expireTime != "20990801-16:00:00.000" {
+		t.Errorf("Expected expire time 20990801-16:00:00.000, got %s", expireTime)
+	}
+}
+
+func TestBuildNewTwapWithoutPrice(t *testing.T) {
+	config := constants.NewConfig()
+	msg, err := BuildNew("BTC-USD", "TWAP", "SELL", "QUOTE", "10000", "", "test-portfolio", config,
+		"2099-08-01T10:00:00Z", "2099-08-01T16:00:00Z")
+
+	if err != nil {
+		t.Fatalf("BuildNew returned error: %v", err)
+	}
+
+	ordType, _ := msg.Body.GetString(constants.TagOrdType)
+	if ordType != constants.OrdTypeMarketFix {
+		t.Errorf("Expected order type %s, got %s", constants.OrdTypeMarketFix, ordType)
+	}
+
+	if msg.Body.Has(constants.TagPx) {
+		t.Error("Expected no price on a TWAP order without a limit")
+	}
+
+	cashQty, _ := msg.Body.GetString(constants.TagCashOrderQty)
+	if cashQty != "10000" {
+		t.Errorf("Expected cash order quantity 10000, got %s", cashQty)
+	}
+}
+
+func TestBuildNewTwapRequiresSchedule(t *testing.T) {
+	config := constants.NewConfig()
+	if _, err := BuildNew("BTC-USD", "TWAP", "BUY", "BASE", "1.0", "", "test-portfolio", config,
+		"2099-08-01T10:00:00Z"); err == nil {
+		t.Error("Expected error for TWAP order without expire time")
+	}
+}
+
+func TestBuildCancelReplaceTwap(t *testing.T) {
+	config := constants.NewConfig()
+	info := model.OrderInfo{
+		OrigClOrdId: "111",
+		OrderId:     "order-1",
+		Side:        constants.SideBuyFix,
+		Symbol:      "BTC-USD",
+		Quantity:    "3.0",
+		OrdType:     constants.OrdTypeTwap,
+		QtyType:     "BASE",
+		StartTime:   "2099-08-01T10:00:00Z",
+		ExpireTime:  "2099-08-01T18:00:00Z",
+	}
+	msg, err := BuildCancelReplace(info, "test-portfolio", config)
+	if err != nil {
+		t.Fatalf("BuildCancelReplace returned error: %v", err)
+	}
+
+	targetStrategy, _ := msg.Body.GetString(constants.TagTargetStrategy)
+	if targetStrategy != constants.TargetStrategyTwap {
+		t.Errorf("Expected target strategy %s, got %s", constants.TargetStrategyTwap, targetStrategy)
+	}
+
+	expireTime, _ := msg.Body.GetString(constants.TagExpireTime)
+	if expireTime != "20990801-18:00:00.000" {
+		t.Errorf("Expected expire time 20990801-18:00:00.000, got %s", expireTime)
+	}
+}
@@ -0,0 +1,44 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"net"
+	"testing"
+
+	"prime-fix-go/api"
+	"prime-fix-go/constants"
+	"prime-fix-go/fixclient"
+	"prime-fix-go/store"
+)
+
+func TestServeReportsAddressInUse(t *testing.T) {
+	taken, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer taken.Close()
+
+	app := fixclient.NewFixApp(constants.NewConfig(), store.NewMemoryStore())
+	server, err := api.NewServer(app, taken.Addr().String(), "token")
+	if err != nil {
+		t.Fatalf("NewServer returned error: %v", err)
+	}
+	if err := serve(server); err == nil {
+		t.Error("Expected serve to fail on an address already in use")
+	}
+}
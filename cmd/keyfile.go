@@ -0,0 +1,68 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"prime-fix-go/config"
+	"prime-fix-go/credentials"
+)
+
+// writeKeyfile encrypts the credentials the configuration supplies, other
+// than through a keyfile, into a new keyfile at path under a passphrase
+// read twice from the terminal.
+func writeKeyfile(cfg *config.Config, path string) int {
+	cfg.KeyFile = ""
+	source, err := cfg.CredentialSource(nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "credential error:", err)
+		return 1
+	}
+	creds, err := source.Fetch()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "credential error:", err)
+		return 1
+	}
+	defer creds.Zero()
+
+	passphrase, err := credentials.ReadPassphrase(fmt.Sprintf("New passphrase for %s: ", path))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "keyfile error:", err)
+		return 1
+	}
+	defer clear(passphrase)
+	again, err := credentials.ReadPassphrase("Repeat the passphrase: ")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "keyfile error:", err)
+		return 1
+	}
+	defer clear(again)
+	if len(passphrase) == 0 || !bytes.Equal(passphrase, again) {
+		fmt.Fprintln(os.Stderr, "keyfile error: the passphrases are empty or do not match")
+		return 1
+	}
+
+	if err := credentials.WriteKeyfile(path, creds, passphrase); err != nil {
+		fmt.Fprintln(os.Stderr, "keyfile error:", err)
+		return 1
+	}
+	fmt.Fprintf(os.Stderr, "Wrote %s. Set keyFile to it and remove the plain credentials from the environment and config file.\n", path)
+	return exitOk
+}
@@ -17,41 +17,213 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
+	"os"
+	"slices"
+	"strings"
 
-	"prime-fix-go/constants"
+	"prime-fix-go/api"
+	"prime-fix-go/config"
+	"prime-fix-go/credentials"
 	"prime-fix-go/fixclient"
 	"prime-fix-go/formatter"
+	"prime-fix-go/instruments"
+	"prime-fix-go/quotepolicy"
+	"prime-fix-go/risk"
+	"prime-fix-go/sessionstore"
+	"prime-fix-go/store"
 	"prime-fix-go/utils"
 
 	"github.com/quickfixgo/quickfix"
 )
 
 func main() {
-	fmt.Printf("%s\n\n", utils.FullVersion())
+	os.Exit(run())
+}
+
+// run starts the client and returns the process exit status.
+func run() int {
+	flag.Usage = usage
+	configPath := flag.String("config", "", "configuration `file`: JSON, or a QuickFIX settings file (default config.json if present, else fix.cfg)")
+	profile := flag.String("env", "", "configuration profile `name`, e.g. sandbox (default the file's profile)")
+	ordersFile := flag.String("orders-file", "", "order cache `path`, overriding orderStorePath")
+	logFormat := flag.String("log-format", formatter.LogFormatTable, "FIX message log `format`: "+strings.Join(formatter.LogFormats, ", "))
+	daemon := flag.Bool("daemon", false, "run headless, serving the HTTP API instead of the REPL")
+	scriptPath := flag.String("script", "", "run the commands in `file` (- for stdin) instead of the REPL; piped stdin is run as a script too")
+	ackTimeout := flag.Duration("ack-timeout", fixclient.DefaultAckTimeout, "how long to wait for logon and for each order or RFQ to be acknowledged")
+	jsonOutput := flag.Bool("json", false, "print the result of a one-shot command as JSON")
+	keyfilePath := flag.String("write-keyfile", "", "encrypt the configured credentials into the keyfile at `path` and exit")
+	flag.Parse()
+
+	// A one-shot command prints only its result on stdout; everything else
+	// goes to stderr.
+	oneShot := flag.NArg() > 0
+	if oneShot && !slices.Contains(fixclient.OneShotCommands, strings.ToLower(flag.Arg(0))) {
+		fmt.Fprintf(os.Stderr, "unknown command %q (%s)\n", flag.Arg(0), strings.Join(fixclient.OneShotCommands, ", "))
+		return exitInvalid
+	}
+	var console io.Writer = os.Stdout
+	if oneShot {
+		console = os.Stderr
+	}
+
+	fmt.Fprintf(console, "%s\n\n", utils.FullVersion())
+
+	if *configPath == "" {
+		*configPath = "fix.cfg"
+		if _, err := os.Stat("config.json"); err == nil {
+			*configPath = "config.json"
+		}
+	}
+	cfg, err := config.Load(*configPath, *profile)
+	if err != nil {
+		log.Print("config error: ", err)
+		return 1
+	}
+	if err := cfg.Validate(); err != nil {
+		var invalid *config.ValidationError
+		if !errors.As(err, &invalid) {
+			log.Print(err)
+			return 1
+		}
+		log.Printf("invalid configuration in %s (profile %s):", *configPath, invalid.Profile)
+		for _, p := range invalid.Problems {
+			log.Printf("  %v", p)
+		}
+		return 1
+	}
+	if *keyfilePath != "" {
+		return writeKeyfile(cfg, *keyfilePath)
+	}
+	creds, err := cfg.CredentialSource(credentials.ReadPassphrase)
+	if err != nil {
+		log.Print("credential error: ", err)
+		return 1
+	}
+	defer creds.Close()
+	// Fetch once now, so a missing or unreadable credential stops the client
+	// before it connects rather than failing every Logon.
+	c, err := creds.Fetch()
+	if err != nil {
+		log.Print("credential error: ", err)
+		return 1
+	}
+	c.Zero()
+
+	settings, err := cfg.QuickfixSettings()
+	if err != nil {
+		log.Print("config error: ", err)
+		return 1
+	}
+	logFactory, err := formatter.NewLogFactory(*logFormat, console)
+	if err != nil {
+		log.Print(err)
+		return 1
+	}
+
+	if *ordersFile == "" {
+		*ordersFile = cfg.OrderStorePath
+	}
+	orderStore, err := store.New(cfg.OrderStore, *ordersFile)
+	if err != nil {
+		log.Println("order store error:", err)
+		return 1
+	}
+	defer orderStore.Close()
 
-	settings, err := utils.LoadSettings("fix.cfg")
+	sessionStore, err := sessionstore.NewFactory(cfg.SessionStore, settings)
 	if err != nil {
-		log.Fatal(err)
+		log.Println("session store error:", err)
+		return 1
 	}
 
-	config := constants.NewConfig()
-	app := fixclient.NewFixApp(config)
+	app := fixclient.NewFixApp(cfg.AppConfig(), orderStore)
+	app.SetOutput(console)
+	app.SetCredentials(creds)
+
+	if cfg.RiskLimitsFile != "" {
+		engine, err := risk.LoadEngine(cfg.RiskLimitsFile)
+		if err != nil {
+			log.Println("risk limits error:", err)
+			return 1
+		}
+		app.SetRiskEngine(engine)
+	}
+
+	if cfg.InstrumentsFile != "" {
+		catalog, err := instruments.Load(cfg.InstrumentsFile)
+		if err != nil {
+			log.Println("instrument catalog error:", err)
+			return 1
+		}
+		app.SetInstruments(catalog)
+	}
+
+	if cfg.AutoAcceptPolicyFile != "" {
+		policy, err := quotepolicy.Load(cfg.AutoAcceptPolicyFile)
+		if err != nil {
+			log.Println("auto-accept policy error:", err)
+			return 1
+		}
+		app.SetQuotePolicy(policy)
+	}
+
+	// The daemon always serves the API; the REPL serves it alongside when a
+	// token is configured, so dashboards can follow the event stream.
+	var server *api.Server
+	if *daemon || (cfg.ApiToken != "" && !oneShot) {
+		server, err = api.NewServer(app, cfg.ApiAddress, cfg.ApiToken)
+		if err != nil {
+			log.Println("API error:", err)
+			return 1
+		}
+	}
 
 	initiator, err := quickfix.NewInitiator(app,
-		quickfix.NewMemoryStoreFactory(),
+		sessionStore,
 		settings,
-		formatter.NewTableLogFactory(),
+		logFactory,
 	)
 	if err != nil {
-		log.Fatal("initiator error:", err)
+		log.Println("initiator error:", err)
+		return 1
 	}
 
 	if err := initiator.Start(); err != nil {
-		log.Fatal("start error:", err)
+		log.Println("start error:", err)
+		return 1
 	}
 	defer initiator.Stop()
 
+	if oneShot {
+		return runOnce(app, flag.Args(), *ackTimeout, *jsonOutput, os.Stdout)
+	}
+	if *daemon {
+		if err := serve(server); err != nil {
+			log.Println("API error:", err)
+			return 1
+		}
+		return 0
+	}
+	if server != nil {
+		go func() {
+			if err := server.ListenAndServe(); err != nil {
+				log.Println("API error:", err)
+			}
+		}()
+		defer server.Shutdown(context.Background())
+	}
+	if *scriptPath == "" && !stdinIsTerminal() {
+		*scriptPath = "-"
+	}
+	if *scriptPath != "" {
+		return runScript(app, *scriptPath, *ackTimeout)
+	}
 	fixclient.Repl(app)
+	return 0
 }
@@ -0,0 +1,85 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"prime-fix-go/fixclient"
+	"prime-fix-go/risk"
+)
+
+// Exit statuses of a one-shot command. Errors starting the client exit with 1.
+const (
+	exitOk       = 0
+	exitInvalid  = 2 // invalid command or input, unknown order, or a state that does not allow it
+	exitRejected = 3 // rejected by Prime or blocked by a risk check
+	exitNoAnswer = 4 // not logged on, not sent, or no ExecutionReport in time
+)
+
+// runOnce performs a one-shot command and prints the resulting order to out.
+func runOnce(app *fixclient.FixApp, args []string, timeout time.Duration, asJson bool, out io.Writer) int {
+	info, err := app.RunOnce(args, timeout)
+	if info.ClOrdId != "" {
+		if asJson {
+			enc := json.NewEncoder(out)
+			enc.SetIndent("", "  ")
+			_ = enc.Encode(info)
+		} else {
+			fixclient.PrintOrder(out, info)
+		}
+	}
+	if err == nil {
+		return exitOk
+	}
+	fmt.Fprintln(os.Stderr, "error:", err)
+
+	var blocked *risk.Violation
+	switch {
+	case errors.Is(err, fixclient.ErrRejected), errors.As(err, &blocked):
+		return exitRejected
+	case errors.Is(err, fixclient.ErrTimeout), errors.Is(err, fixclient.ErrNotSent):
+		return exitNoAnswer
+	}
+	return exitInvalid
+}
+
+func usage() {
+	w := flag.CommandLine.Output()
+	fmt.Fprintf(w, `Usage: %s [flags] [command]
+
+Without a command the client starts the REPL, runs a script, or serves the
+API with -daemon. A command logs on, performs one action, waits for the
+ExecutionReport that answers it, prints the order and logs out:
+
+  new <symbol> <ordType> <BUY|SELL> <BASE|QUOTE> <qty> [arguments as in the REPL]
+  cancel <ClOrdId>
+  status <ClOrdId>
+
+Exit status: 0 success, 1 the client could not start, 2 invalid command or
+input, 3 rejected by Prime or blocked by a risk check, 4 no answer in time.
+
+Flags:
+`, os.Args[0])
+	flag.PrintDefaults()
+}
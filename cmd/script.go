@@ -0,0 +1,52 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"prime-fix-go/fixclient"
+)
+
+// runScript runs a command script from path, or stdin for "-", prints a
+// summary and returns 1 if any command failed.
+func runScript(app *fixclient.FixApp, path string, ackTimeout time.Duration) int {
+	var in io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			fmt.Println("script error:", err)
+			return 1
+		}
+		defer f.Close()
+		in = f
+	}
+	result := fixclient.RunScript(app, in, ackTimeout)
+	fmt.Println("script:", result)
+	if result.Failed() {
+		return 1
+	}
+	return 0
+}
+
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,361 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config loads the client configuration from one file plus
+// environment overrides, selects a named profile, validates the result and
+// generates the QuickFIX/Go session settings from it.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/quickfixgo/quickfix"
+	fixconfig "github.com/quickfixgo/quickfix/config"
+
+	"prime-fix-go/constants"
+	"prime-fix-go/credentials"
+)
+
+// DefaultProfile needs no entry under profiles: it is the file's top-level
+// settings as they stand.
+const DefaultProfile = "production"
+
+// Environment variables read by Load. The credential variables override the
+// file; SVC_ACCOUNT_ID and TARGET_COMP_ID must agree with the file when both
+// are set. Each credential can instead be read from the file named by its
+// _FILE variant, e.g. SIGNING_KEY_FILE.
+const (
+	EnvProfile          = "PRIME_FIX_PROFILE"
+	EnvSvcAccountId     = "SVC_ACCOUNT_ID"
+	EnvTargetCompId     = "TARGET_COMP_ID"
+	EnvAccessKey        = "ACCESS_KEY"
+	EnvSigningKey       = "SIGNING_KEY"
+	EnvPassphrase       = "PASSPHRASE"
+	EnvPortfolioId      = "PORTFOLIO_ID"
+	EnvHost             = "PRIME_FIX_HOST"
+	EnvPort             = "PRIME_FIX_PORT"
+	EnvApiToken         = "PRIME_FIX_API_TOKEN"
+	EnvKeyFile          = "PRIME_FIX_KEYFILE"
+	EnvCredentialHelper = "PRIME_FIX_CREDENTIAL_HELPER"
+
+	fileSuffix = "_FILE"
+)
+
+// Connection holds the FIX connection settings.
+type Connection struct {
+	Host              string `json:"host,omitempty"`
+	Port              int    `json:"port,omitempty"`
+	Tls               bool   `json:"tls"`
+	CaFile            string `json:"caFile,omitempty"`
+	ServerName        string `json:"serverName,omitempty"`
+	HeartBtInt        int    `json:"heartBtInt,omitempty"`
+	ReconnectInterval int    `json:"reconnectInterval,omitempty"`
+	ResetOnLogon      bool   `json:"resetOnLogon"`
+	// DataDictionary is the FIX 4.2 data dictionary; empty disables it.
+	DataDictionary string `json:"dataDictionary,omitempty"`
+}
+
+// Config is the whole client configuration. In the file, each entry under
+// profiles holds the settings that profile changes, in the same layout.
+type Config struct {
+	Profile string `json:"profile,omitempty"`
+
+	SenderCompId string `json:"senderCompId,omitempty"`
+	TargetCompId string `json:"targetCompId,omitempty"`
+	PortfolioId  string `json:"portfolioId,omitempty"`
+	AccessKey    string `json:"accessKey,omitempty"`
+	SigningKey   string `json:"signingKey,omitempty"`
+	Passphrase   string `json:"passphrase,omitempty"`
+
+	// Credentials can also come from files, an encrypted keyfile or a
+	// helper command; see CredentialSource.
+	AccessKeyFile    string `json:"accessKeyFile,omitempty"`
+	SigningKeyFile   string `json:"signingKeyFile,omitempty"`
+	PassphraseFile   string `json:"passphraseFile,omitempty"`
+	KeyFile          string `json:"keyFile,omitempty"`
+	CredentialHelper string `json:"credentialHelper,omitempty"`
+
+	Connection Connection `json:"connection"`
+
+	SessionStore   string `json:"sessionStore,omitempty"`
+	FileStorePath  string `json:"fileStorePath,omitempty"`
+	ResendMaxAge   string `json:"resendMaxAge,omitempty"`
+	OrderStore     string `json:"orderStore,omitempty"`
+	OrderStorePath string `json:"orderStorePath,omitempty"`
+
+	AutoAcceptQuotes     bool   `json:"autoAcceptQuotes"`
+	AutoAcceptPolicyFile string `json:"autoAcceptPolicyFile,omitempty"`
+	RiskLimitsFile       string `json:"riskLimitsFile,omitempty"`
+	InstrumentsFile      string `json:"instrumentsFile,omitempty"`
+	RoundToTick          bool   `json:"roundToTick"`
+
+	ApiAddress string `json:"apiAddress,omitempty"`
+	ApiToken   string `json:"apiToken,omitempty"`
+
+	// Settings are passed to QuickFIX/Go as they are, after the generated ones.
+	Settings map[string]string `json:"settings,omitempty"`
+
+	Profiles map[string]json.RawMessage `json:"profiles,omitempty"`
+
+	// conflicts are inconsistencies found while loading, reported by Validate.
+	conflicts []*Problem
+}
+
+// Default returns the configuration every file starts from.
+func Default() *Config {
+	return &Config{
+		Profile:      DefaultProfile,
+		TargetCompId: constants.DefaultTargetCompId,
+		Connection: Connection{
+			Host:              "fix.prime.coinbase.com",
+			Port:              4198,
+			Tls:               true,
+			HeartBtInt:        30,
+			ReconnectInterval: 10,
+			DataDictionary:    "FIX42.xml",
+		},
+		SessionStore:  "file",
+		FileStorePath: "./Sessions/",
+		ResendMaxAge:  constants.DefaultResendMaxAge.String(),
+		OrderStore:    "json",
+		ApiAddress:    "127.0.0.1:8642",
+	}
+}
+
+// Load reads the configuration at path, applies the named profile and then
+// the environment. An empty profile falls back to PRIME_FIX_PROFILE and then
+// to the file's profile setting. A JSON file uses the layout of Config;
+// anything else is read as a QuickFIX/Go settings file such as fix.cfg,
+// which has no profiles. Load does not validate; call Validate.
+func Load(path, profile string) (*Config, error) {
+	return load(path, profile, os.Getenv)
+}
+
+func load(path, profile string, getenv func(string) string) (*Config, error) {
+	c := Default()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if isJson(data) {
+		if err := json.Unmarshal(data, c); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	} else if err := c.loadSettingsFile(path); err != nil {
+		return nil, err
+	}
+
+	if profile == "" {
+		profile = getenv(EnvProfile)
+	}
+	if profile != "" {
+		c.Profile = profile
+	}
+	if raw, ok := c.Profiles[c.Profile]; ok {
+		selected := c.Profile
+		if err := json.Unmarshal(raw, c); err != nil {
+			return nil, fmt.Errorf("%s: profile %s: %w", path, selected, err)
+		}
+		c.Profile = selected
+	} else if c.Profile != DefaultProfile {
+		c.conflicts = append(c.conflicts, &Problem{"profile", fmt.Sprintf("%q is not defined (%s)", c.Profile, strings.Join(c.ProfileNames(), ", "))})
+	}
+
+	c.applyEnv(getenv)
+	return c, nil
+}
+
+// ProfileNames lists the profiles the file defines, with the default profile.
+func (c *Config) ProfileNames() []string {
+	names := []string{DefaultProfile}
+	for name := range c.Profiles {
+		if name != DefaultProfile {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names[1:])
+	return names
+}
+
+// applyEnv overrides the file with the environment.
+func (c *Config) applyEnv(getenv func(string) string) {
+	c.matchEnv(getenv, EnvSvcAccountId, "senderCompId", &c.SenderCompId)
+	c.matchEnv(getenv, EnvTargetCompId, "targetCompId", &c.TargetCompId)
+	for _, v := range []struct {
+		name string
+		dst  *string
+	}{
+		{EnvAccessKey, &c.AccessKey},
+		{EnvSigningKey, &c.SigningKey},
+		{EnvPassphrase, &c.Passphrase},
+		{EnvAccessKey + fileSuffix, &c.AccessKeyFile},
+		{EnvSigningKey + fileSuffix, &c.SigningKeyFile},
+		{EnvPassphrase + fileSuffix, &c.PassphraseFile},
+		{EnvKeyFile, &c.KeyFile},
+		{EnvCredentialHelper, &c.CredentialHelper},
+		{EnvPortfolioId, &c.PortfolioId},
+		{EnvHost, &c.Connection.Host},
+		{EnvApiToken, &c.ApiToken},
+	} {
+		if value := getenv(v.name); value != "" {
+			*v.dst = value
+		}
+	}
+	if value := getenv(EnvPort); value != "" {
+		port, err := strconv.Atoi(value)
+		if err != nil {
+			c.conflicts = append(c.conflicts, &Problem{EnvPort, fmt.Sprintf("%q is not a port number", value)})
+		} else {
+			c.Connection.Port = port
+		}
+	}
+}
+
+// matchEnv sets a session identifier from the environment, recording a
+// conflict when the file already holds a different value. The default
+// TargetCompID does not count as set by the file.
+func (c *Config) matchEnv(getenv func(string) string, name, key string, dst *string) {
+	value := getenv(name)
+	if value == "" {
+		return
+	}
+	fromFile := *dst != "" && !(key == "targetCompId" && *dst == constants.DefaultTargetCompId)
+	if fromFile && *dst != value {
+		c.conflicts = append(c.conflicts, &Problem{name, fmt.Sprintf("%q does not match %s %q in the config file", value, key, *dst)})
+		return
+	}
+	*dst = value
+}
+
+// isJson tells a JSON configuration from a QuickFIX settings file, whose
+// first line is a section header or a comment.
+func isJson(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && data[0] == '{'
+}
+
+// AppConfig returns the settings the FIX application reads.
+func (c *Config) AppConfig() *constants.Config {
+	config := &constants.Config{
+		SenderCompId:     c.SenderCompId,
+		TargetCompId:     c.TargetCompId,
+		PortfolioId:      c.PortfolioId,
+		ResendMaxAge:     constants.DefaultResendMaxAge,
+		AutoAcceptQuotes: c.AutoAcceptQuotes,
+		RoundToTick:      c.RoundToTick,
+	}
+	if d, err := parseDuration(c.ResendMaxAge); err == nil {
+		config.ResendMaxAge = d
+	}
+	return config
+}
+
+// CredentialSource returns the sources of the Logon credentials, asked in
+// order for whatever is still missing: values in the file or environment,
+// the *_FILE files, the keyfile and then the credential helper. The keyfile
+// is decrypted here with a passphrase read by prompt; close the chain to
+// zero it.
+func (c *Config) CredentialSource(prompt func(string) ([]byte, error)) (credentials.Chain, error) {
+	chain := credentials.Chain{
+		credentials.Static{AccessKey: c.AccessKey, SigningKey: c.SigningKey, Passphrase: c.Passphrase},
+		credentials.Files{AccessKey: c.AccessKeyFile, SigningKey: c.SigningKeyFile, Passphrase: c.PassphraseFile},
+	}
+	if c.KeyFile != "" {
+		passphrase, err := prompt(fmt.Sprintf("Passphrase for %s: ", c.KeyFile))
+		if err != nil {
+			return nil, err
+		}
+		keyfile, err := credentials.OpenKeyfile(c.KeyFile, passphrase)
+		clear(passphrase)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, keyfile)
+	}
+	if c.CredentialHelper != "" {
+		chain = append(chain, credentials.Helper{
+			Command: c.CredentialHelper,
+			Input: map[string]string{
+				"profile":      c.Profile,
+				"senderCompId": c.SenderCompId,
+				"portfolioId":  c.PortfolioId,
+			},
+		})
+	}
+	return chain, nil
+}
+
+// QuickfixSettings generates the QuickFIX/Go settings for the single Prime
+// session.
+func (c *Config) QuickfixSettings() (*quickfix.Settings, error) {
+	settings := quickfix.NewSettings()
+	global := settings.GlobalSettings()
+	conn := c.Connection
+	global.Set(fixconfig.SocketConnectHost, conn.Host)
+	global.Set(fixconfig.SocketConnectPort, strconv.Itoa(conn.Port))
+	global.Set(fixconfig.SocketUseSSL, yesNo(conn.Tls))
+	if conn.Tls {
+		global.Set(fixconfig.SocketMinimumTLSVersion, "TLS12")
+		global.Set(fixconfig.SocketServerName, orDefault(conn.ServerName, conn.Host))
+		if conn.CaFile != "" {
+			global.Set(fixconfig.SocketCAFile, conn.CaFile)
+		}
+	}
+	global.Set(fixconfig.HeartBtInt, strconv.Itoa(conn.HeartBtInt))
+	global.Set(fixconfig.ReconnectInterval, strconv.Itoa(conn.ReconnectInterval))
+	global.Set(fixconfig.ResetOnLogon, yesNo(conn.ResetOnLogon))
+	global.Set(fixconfig.StartTime, "00:00:00")
+	global.Set(fixconfig.EndTime, "00:00:00")
+	if conn.DataDictionary != "" {
+		global.Set(fixconfig.DataDictionary, conn.DataDictionary)
+	}
+	global.Set(fixconfig.CheckUserDefinedFields, "N")
+	for key, value := range c.Settings {
+		global.Set(key, value)
+	}
+
+	session := quickfix.NewSessionSettings()
+	session.Set(fixconfig.BeginString, quickfix.BeginStringFIX42)
+	session.Set(fixconfig.SenderCompID, c.SenderCompId)
+	session.Set(fixconfig.TargetCompID, c.TargetCompId)
+	if c.FileStorePath != "" {
+		session.Set(fixconfig.FileStorePath, c.FileStorePath)
+	}
+	if _, err := settings.AddSession(session); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "Y"
+	}
+	return "N"
+}
+
+func orDefault(value, def string) string {
+	if value == "" {
+		return def
+	}
+	return value
+}
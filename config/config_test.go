@@ -0,0 +1,207 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	fixconfig "github.com/quickfixgo/quickfix/config"
+)
+
+const configJson = `{
+  "profile": "sandbox",
+  "senderCompId": "svc-1",
+  "portfolioId": "portfolio-1",
+  "orderStore": "memory",
+  "connection": {"host": "fix.prime.coinbase.com", "dataDictionary": ""},
+  "profiles": {
+    "sandbox": {"connection": {"host": "fix.sandbox.example", "port": 4199}, "roundToTick": true}
+  }
+}`
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func env(values map[string]string) func(string) string {
+	return func(name string) string { return values[name] }
+}
+
+var secrets = map[string]string{
+	EnvAccessKey:  "key",
+	EnvSigningKey: "secret",
+	EnvPassphrase: "pass",
+}
+
+func TestLoadAppliesProfileAndEnvironment(t *testing.T) {
+	path := writeFile(t, "config.json", configJson)
+	c, err := load(path, "", env(secrets))
+	if err != nil {
+		t.Fatalf("load returned error: %v", err)
+	}
+	if c.Profile != "sandbox" || c.Connection.Host != "fix.sandbox.example" || c.Connection.Port != 4199 || !c.RoundToTick {
+		t.Errorf("Expected the sandbox profile applied, got %s %s:%d roundToTick=%v",
+			c.Profile, c.Connection.Host, c.Connection.Port, c.RoundToTick)
+	}
+	if c.AccessKey != "key" || c.TargetCompId != "COIN" {
+		t.Errorf("Expected credentials from the environment and the default TargetCompID, got %q %q", c.AccessKey, c.TargetCompId)
+	}
+	if err := c.Validate(); err != nil {
+		t.Errorf("Validate returned error: %v", err)
+	}
+
+	c, err = load(path, DefaultProfile, env(map[string]string{EnvHost: "127.0.0.1", EnvPort: "9000"}))
+	if err != nil {
+		t.Fatalf("load returned error: %v", err)
+	}
+	if c.Connection.Host != "127.0.0.1" || c.Connection.Port != 9000 || c.RoundToTick {
+		t.Errorf("Expected production with the host from the environment, got %s:%d roundToTick=%v",
+			c.Connection.Host, c.Connection.Port, c.RoundToTick)
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	path := writeFile(t, "config.json", `{"senderCompId": "svc-1", "orderStore": "sql", "resendMaxAge": "soon",
+		"connection": {"port": 0, "dataDictionary": ""}}`)
+	c, err := load(path, "staging", env(map[string]string{EnvSvcAccountId: "svc-2"}))
+	if err != nil {
+		t.Fatalf("load returned error: %v", err)
+	}
+	err = c.Validate()
+	var invalid *ValidationError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("Expected a *ValidationError, got %v", err)
+	}
+	keys := map[string]bool{}
+	for _, p := range invalid.Problems {
+		keys[p.Key] = true
+	}
+	for _, key := range []string{"profile", EnvSvcAccountId, "accessKey", "signingKey", "passphrase", "portfolioId",
+		"connection.port", "orderStore", "resendMaxAge"} {
+		if !keys[key] {
+			t.Errorf("Expected a problem with %s in %v", key, err)
+		}
+	}
+}
+
+func TestLoadSettingsFile(t *testing.T) {
+	c, err := load("../fix.cfg.example", "", env(nil))
+	if err != nil {
+		t.Fatalf("load returned error: %v", err)
+	}
+	if c.SenderCompId != "YOUR_SVC_ACCOUNT_ID" || c.Connection.Host != "fix.prime.coinbase.com" || c.Connection.Port != 4198 {
+		t.Errorf("Unexpected session %s %s:%d", c.SenderCompId, c.Connection.Host, c.Connection.Port)
+	}
+	if c.FileStorePath != "./Sessions/" || c.OrderStorePath != "orders.json" || c.ApiAddress != "127.0.0.1:8642" {
+		t.Errorf("Unexpected client settings %+v", c)
+	}
+
+	settings, err := c.QuickfixSettings()
+	if err != nil {
+		t.Fatalf("QuickfixSettings returned error: %v", err)
+	}
+	for id, session := range settings.SessionSettings() {
+		if id.SenderCompID != "YOUR_SVC_ACCOUNT_ID" || id.TargetCompID != "COIN" || id.BeginString != "FIX.4.2" {
+			t.Errorf("Unexpected session %v", id)
+		}
+		if v, _ := session.Setting(fixconfig.SocketConnectHost); v != "fix.prime.coinbase.com" {
+			t.Errorf("Expected the host in the generated settings, got %q", v)
+		}
+		if v, _ := session.Setting(fixconfig.SocketCAFile); v != "/Users/yourname/system-roots.pem" {
+			t.Errorf("Expected the CA file in the generated settings, got %q", v)
+		}
+	}
+}
+
+func TestEnvironmentMustMatchFile(t *testing.T) {
+	path := writeFile(t, "config.json", `{"senderCompId": "svc-1"}`)
+	c, err := load(path, "", env(map[string]string{EnvSvcAccountId: "svc-1", EnvTargetCompId: "COIN"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.conflicts) != 0 {
+		t.Errorf("Expected matching values to agree, got %v", c.conflicts)
+	}
+}
+
+func TestLoadExampleProfile(t *testing.T) {
+	c, err := load("../config.json.example", "sandbox", env(nil))
+	if err != nil {
+		t.Fatalf("load returned error: %v", err)
+	}
+	if c.SenderCompId != "YOUR_SANDBOX_SVC_ACCOUNT_ID" || c.Connection.Host != "YOUR_SANDBOX_FIX_HOST" {
+		t.Errorf("Expected the sandbox session, got %s %s", c.SenderCompId, c.Connection.Host)
+	}
+	if c.Connection.Port != 4198 || !c.Connection.Tls || c.OrderStorePath != "orders.sandbox.json" {
+		t.Errorf("Expected the profile over the top-level settings, got %+v", c)
+	}
+	if names := c.ProfileNames(); len(names) != 2 {
+		t.Errorf("Expected production and sandbox, got %v", names)
+	}
+}
+
+func TestValidateCredentialSources(t *testing.T) {
+	path := writeFile(t, "config.json", configJson)
+	secret := writeFile(t, "signing_key", "secret\n")
+	open := writeFile(t, "passphrase", "pass\n")
+	if err := os.Chmod(open, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := load(path, "", env(map[string]string{
+		EnvAccessKey:               "key",
+		EnvAccessKey + fileSuffix:  secret,
+		EnvSigningKey + fileSuffix: secret,
+		EnvPassphrase + fileSuffix: open,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var invalid *ValidationError
+	if !errors.As(c.Validate(), &invalid) {
+		t.Fatal("Expected a *ValidationError")
+	}
+	keys := map[string]bool{}
+	for _, p := range invalid.Problems {
+		keys[p.Key] = true
+	}
+	if len(keys) != 2 || !keys["accessKey"] || !keys["passphraseFile"] {
+		t.Errorf("Expected accessKey set twice and an insecure passphraseFile, got %v", invalid)
+	}
+
+	c, err = load(path, "", env(map[string]string{EnvSigningKey + fileSuffix: secret, EnvCredentialHelper: "helper"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Validate(); err != nil {
+		t.Errorf("Expected the helper to stand in for the missing credentials, got %v", err)
+	}
+	source, err := c.CredentialSource(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(source) != 3 {
+		t.Errorf("Expected static, file and helper sources, got %d", len(source))
+	}
+}
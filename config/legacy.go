@@ -0,0 +1,156 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	fixconfig "github.com/quickfixgo/quickfix/config"
+)
+
+// loadSettingsFile reads a QuickFIX/Go settings file such as fix.cfg. Settings
+// Config models are taken from [DEFAULT] and the [SESSION]; any other setting
+// is kept in Settings and passed through.
+func (c *Config) loadSettingsFile(path string) error {
+	values, err := readSettingsFile(path)
+	if err != nil {
+		return err
+	}
+
+	strs := map[string]*string{
+		fixconfig.SenderCompID:      &c.SenderCompId,
+		fixconfig.TargetCompID:      &c.TargetCompId,
+		fixconfig.SocketConnectHost: &c.Connection.Host,
+		fixconfig.SocketCAFile:      &c.Connection.CaFile,
+		"SSLCAFile":                 &c.Connection.CaFile,
+		fixconfig.SocketServerName:  &c.Connection.ServerName,
+		"SSLServerName":             &c.Connection.ServerName,
+		fixconfig.DataDictionary:    &c.Connection.DataDictionary,
+		fixconfig.FileStorePath:     &c.FileStorePath,
+		"SessionStore":              &c.SessionStore,
+		"ResendMaxAge":              &c.ResendMaxAge,
+		"OrderStore":                &c.OrderStore,
+		"OrderStorePath":            &c.OrderStorePath,
+		"AutoAcceptPolicyFile":      &c.AutoAcceptPolicyFile,
+		"RiskLimitsFile":            &c.RiskLimitsFile,
+		"InstrumentsFile":           &c.InstrumentsFile,
+		"ApiAddress":                &c.ApiAddress,
+		"ApiToken":                  &c.ApiToken,
+		"AccessKeyFile":             &c.AccessKeyFile,
+		"SigningKeyFile":            &c.SigningKeyFile,
+		"PassphraseFile":            &c.PassphraseFile,
+		"KeyFile":                   &c.KeyFile,
+		"CredentialHelper":          &c.CredentialHelper,
+	}
+	ints := map[string]*int{
+		fixconfig.SocketConnectPort: &c.Connection.Port,
+		fixconfig.HeartBtInt:        &c.Connection.HeartBtInt,
+		fixconfig.ReconnectInterval: &c.Connection.ReconnectInterval,
+	}
+	bools := map[string]*bool{
+		fixconfig.SocketUseSSL: &c.Connection.Tls,
+		fixconfig.ResetOnLogon: &c.Connection.ResetOnLogon,
+		"AutoAcceptQuotes":     &c.AutoAcceptQuotes,
+		"RoundToTick":          &c.RoundToTick,
+	}
+	// Generated from the settings above, fixed for Prime, or not read by
+	// QuickFIX/Go.
+	generated := map[string]bool{
+		fixconfig.BeginString:             true,
+		fixconfig.SocketMinimumTLSVersion: true,
+		"UseDataDictionary":               true,
+		"ConnectionType":                  true,
+		"SSLProtocols":                    true,
+		"SSLVerifyCertificates":           true,
+		"ValidateIncomingMessage":         true,
+	}
+
+	for key, value := range values {
+		switch {
+		case strs[key] != nil:
+			*strs[key] = value
+		case ints[key] != nil:
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				c.conflicts = append(c.conflicts, &Problem{key, fmt.Sprintf("%q is not a number", value)})
+				continue
+			}
+			*ints[key] = n
+		case bools[key] != nil:
+			*bools[key] = strings.EqualFold(value, "Y")
+		case generated[key]:
+		default:
+			if c.Settings == nil {
+				c.Settings = map[string]string{}
+			}
+			c.Settings[key] = value
+		}
+	}
+	if strings.EqualFold(values["UseDataDictionary"], "N") {
+		c.Connection.DataDictionary = ""
+	}
+	if v := values[fixconfig.BeginString]; v != "" && v != "FIX.4.2" {
+		c.conflicts = append(c.conflicts, &Problem{fixconfig.BeginString, fmt.Sprintf("%q is not supported; Prime uses FIX.4.2", v)})
+	}
+	return nil
+}
+
+// readSettingsFile returns the settings of the [DEFAULT] and [SESSION]
+// sections, with session values taking precedence.
+func readSettingsFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	global, session := map[string]string{}, map[string]string{}
+	var section map[string]string
+	sessions := 0
+	scanner := bufio.NewScanner(f)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+		case strings.EqualFold(line, "[DEFAULT]"):
+			section = global
+		case strings.EqualFold(line, "[SESSION]"):
+			section = session
+			sessions++
+		default:
+			key, value, found := strings.Cut(line, "=")
+			if !found || section == nil {
+				return nil, fmt.Errorf("%s: error parsing line %d", path, lineNo)
+			}
+			section[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if sessions != 1 {
+		return nil, fmt.Errorf("%s: expected one [SESSION], found %d", path, sessions)
+	}
+	for key, value := range session {
+		global[key] = value
+	}
+	return global, nil
+}
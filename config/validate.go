@@ -0,0 +1,170 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"prime-fix-go/credentials"
+	"prime-fix-go/store"
+)
+
+// Problem is one missing or inconsistent setting. Key is the setting's name
+// in the config file, or the environment variable it came from.
+type Problem struct {
+	Key    string
+	Reason string
+}
+
+func (p *Problem) Error() string {
+	return p.Key + ": " + p.Reason
+}
+
+// ValidationError lists every problem found in a configuration.
+type ValidationError struct {
+	Profile  string
+	Problems []*Problem
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		msgs[i] = p.Error()
+	}
+	return fmt.Sprintf("invalid configuration (profile %s): %s", e.Profile, strings.Join(msgs, "; "))
+}
+
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Problems))
+	for i, p := range e.Problems {
+		errs[i] = p
+	}
+	return errs
+}
+
+// Validate reports every missing or inconsistent value as a
+// *ValidationError, so all of them can be fixed before connecting.
+func (c *Config) Validate() error {
+	problems := append([]*Problem(nil), c.conflicts...)
+	add := func(key, format string, args ...any) {
+		problems = append(problems, &Problem{key, fmt.Sprintf(format, args...)})
+	}
+
+	for _, v := range []struct {
+		key, env, value string
+	}{
+		{"senderCompId", EnvSvcAccountId, c.SenderCompId},
+		{"targetCompId", EnvTargetCompId, c.TargetCompId},
+		{"portfolioId", EnvPortfolioId, c.PortfolioId},
+	} {
+		if strings.TrimSpace(v.value) == "" {
+			add(v.key, "is required; set it in the config file or %s", v.env)
+		}
+	}
+
+	// A keyfile or helper may supply any credential, which is only known
+	// once it is asked.
+	fromSource := c.KeyFile != "" || c.CredentialHelper != ""
+	for _, v := range []struct {
+		key, env, value, file string
+	}{
+		{"accessKey", EnvAccessKey, c.AccessKey, c.AccessKeyFile},
+		{"signingKey", EnvSigningKey, c.SigningKey, c.SigningKeyFile},
+		{"passphrase", EnvPassphrase, c.Passphrase, c.PassphraseFile},
+	} {
+		switch {
+		case v.value != "" && v.file != "":
+			add(v.key, "is set both directly and as a file (%s); use one", v.file)
+		case v.file != "":
+			if err := credentials.CheckFile(v.file); err != nil {
+				add(v.key+"File", "%v", err)
+			}
+		case strings.TrimSpace(v.value) == "" && !fromSource:
+			add(v.key, "is required; set %s or %s%s, or configure keyFile or credentialHelper", v.env, v.env, fileSuffix)
+		}
+	}
+	if c.KeyFile != "" {
+		if err := credentials.CheckFile(c.KeyFile); err != nil {
+			add("keyFile", "%v", err)
+		}
+	}
+
+	conn := c.Connection
+	if conn.Host == "" {
+		add("connection.host", "is required")
+	}
+	if conn.Port < 1 || conn.Port > 65535 {
+		add("connection.port", "%d is not a port number", conn.Port)
+	}
+	if conn.HeartBtInt <= 0 {
+		add("connection.heartBtInt", "must be a positive number of seconds")
+	}
+	if conn.ReconnectInterval <= 0 {
+		add("connection.reconnectInterval", "must be a positive number of seconds")
+	}
+	if conn.CaFile != "" && !conn.Tls {
+		add("connection.caFile", "is set but tls is off")
+	}
+
+	switch strings.ToLower(c.SessionStore) {
+	case "memory", "file", "journal":
+	default:
+		add("sessionStore", "%q must be memory, file or journal", c.SessionStore)
+	}
+	if !strings.EqualFold(c.SessionStore, "memory") && c.FileStorePath == "" {
+		add("fileStorePath", "is required for the %s session store", c.SessionStore)
+	}
+	switch strings.ToLower(c.OrderStore) {
+	case store.TypeJson, store.TypeJournal, store.TypeMemory:
+	default:
+		add("orderStore", "%q must be json, journal or memory", c.OrderStore)
+	}
+	if _, err := parseDuration(c.ResendMaxAge); err != nil {
+		add("resendMaxAge", "%q is not a duration such as 30s", c.ResendMaxAge)
+	}
+
+	for _, f := range []struct{ key, path string }{
+		{"connection.caFile", conn.CaFile},
+		{"connection.dataDictionary", conn.DataDictionary},
+		{"riskLimitsFile", c.RiskLimitsFile},
+		{"instrumentsFile", c.InstrumentsFile},
+		{"autoAcceptPolicyFile", c.AutoAcceptPolicyFile},
+	} {
+		if f.path == "" {
+			continue
+		}
+		if _, err := os.Stat(f.path); err != nil {
+			add(f.key, "%v", err)
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Profile: c.Profile, Problems: problems}
+}
+
+func parseDuration(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err == nil && d < 0 {
+		err = fmt.Errorf("negative duration")
+	}
+	return d, err
+}
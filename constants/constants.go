@@ -19,62 +19,82 @@ package constants
 import (
 	"github.com/quickfixgo/quickfix"
 	"os"
+	"time"
 )
 
 type Config struct {
 	SenderCompId string
 	TargetCompId string
-	AccessKey    string
-	SigningKey   string
-	Passphrase   string
 	PortfolioId  string
+
+	// ResendMaxAge is how old an order message may be and still be resent in
+	// answer to a ResendRequest; older ones are gap-filled instead.
+	ResendMaxAge time.Duration
+
+	// AutoAcceptQuotes accepts every RFQ quote as soon as it arrives instead
+	// of waiting for an accept command.
+	AutoAcceptQuotes bool
+
+	// RoundToTick moves prices off the instrument tick to the nearest passive
+	// tick instead of rejecting the order.
+	RoundToTick bool
 }
 
 func NewConfig() *Config {
 	return &Config{
 		SenderCompId: os.Getenv("SVC_ACCOUNT_ID"),
 		TargetCompId: os.Getenv("TARGET_COMP_ID"),
-		AccessKey:    os.Getenv("ACCESS_KEY"),
-		SigningKey:   os.Getenv("SIGNING_KEY"),
-		Passphrase:   os.Getenv("PASSPHRASE"),
 		PortfolioId:  os.Getenv("PORTFOLIO_ID"),
+		ResendMaxAge: DefaultResendMaxAge,
 	}
 }
 
 const (
-	MsgTypeNew      = "D" // New Order
-	MsgTypeStatus   = "H" // Status
-	MsgTypeCancel   = "F" // Cancel
-	MsgTypeLogon    = "A" // Logon
-	MsgTypeQuoteReq = "R" // Quote Request
-	MsgTypeQuote    = "S" // Quote
-	MsgTypeQuoteAck = "b" // Quote Acknowledgment
+	MsgTypeExecReport     = "8" // Execution Report
+	MsgTypeCancelReject   = "9" // Order Cancel Reject
+	MsgTypeReject         = "3" // Session Reject
+	MsgTypeBusinessReject = "j" // Business Message Reject
+	MsgTypeNew            = "D" // New Order
+	MsgTypeStatus         = "H" // Status
+	MsgTypeCancel         = "F" // Cancel
+	MsgTypeReplace        = "G" // Cancel/Replace
+	MsgTypeLogon          = "A" // Logon
+	MsgTypeQuoteReq       = "R" // Quote Request
+	MsgTypeQuote          = "S" // Quote
+	MsgTypeQuoteAck       = "b" // Quote Acknowledgment
 
 	FixTimeFormat = "20060102-15:04:05.000"
 
 	DefaultTargetCompId = "COIN"
 
-	OrdTypeLimit  = "LIMIT"
-	OrdTypeMarket = "MARKET"
-	OrdTypeVwap   = "VWAP"
-	OrdTypeRfq    = "RFQ"
-	SideBuy       = "BUY"
-	SideSell      = "SELL"
-
-	OrdTypeLimitFix         = "2" // Limit order type
-	OrdTypeMarketFix        = "1" // Market order type
-	OrdTypeVwapFix          = "2" // VWAP order type (uses limit type)
-	OrdTypePreviouslyQuoted = "D" // Previously Quoted (for RFQ accept)
-	TimeInForceDay          = "1" // Day
-	TimeInForceIoc          = "3" // Immediate or Cancel
-	TimeInForceGtd          = "6" // Good Till Date
-	TimeInForceFok          = "4" // Fill or Kill (for RFQ)
-	TargetStrategyLimit     = "L" // Limit strategy
-	TargetStrategyMarket    = "M" // Market strategy
-	TargetStrategyVwap      = "V" // VWAP strategy
-	TargetStrategyRfq       = "R" // RFQ strategy
-	SideBuyFix              = "1" // Buy side
-	SideSellFix             = "2" // Sell side
+	DefaultResendMaxAge = 30 * time.Second
+
+	OrdTypeLimit     = "LIMIT"
+	OrdTypeMarket    = "MARKET"
+	OrdTypeVwap      = "VWAP"
+	OrdTypeTwap      = "TWAP"
+	OrdTypeStopLimit = "STOP_LIMIT"
+	OrdTypeRfq       = "RFQ"
+	SideBuy          = "BUY"
+	SideSell         = "SELL"
+
+	OrdTypeLimitFix         = "2"  // Limit order type
+	OrdTypeMarketFix        = "1"  // Market order type
+	OrdTypeVwapFix          = "2"  // VWAP order type (uses limit type)
+	OrdTypeStopLimitFix     = "4"  // Stop limit order type
+	OrdTypePreviouslyQuoted = "D"  // Previously Quoted (for RFQ accept)
+	TimeInForceGtc          = "1"  // Good Till Cancel
+	TimeInForceIoc          = "3"  // Immediate or Cancel
+	TimeInForceGtd          = "6"  // Good Till Date
+	TimeInForceFok          = "4"  // Fill or Kill (for RFQ)
+	TargetStrategyLimit     = "L"  // Limit strategy
+	TargetStrategyMarket    = "M"  // Market strategy
+	TargetStrategyVwap      = "V"  // VWAP strategy
+	TargetStrategyTwap      = "T"  // TWAP strategy
+	TargetStrategyStopLimit = "SL" // Stop limit strategy
+	TargetStrategyRfq       = "R"  // RFQ strategy
+	SideBuyFix              = "1"  // Buy side
+	SideSellFix             = "2"  // Sell side
 
 	TagAccount           = quickfix.Tag(1)
 	TagClOrdId           = quickfix.Tag(11)
@@ -84,6 +104,7 @@ const (
 	TagOrigClOrdId       = quickfix.Tag(41)
 	TagTargetStrategy    = quickfix.Tag(847)
 	TagPx                = quickfix.Tag(44)
+	TagStopPx            = quickfix.Tag(99)
 	TagExecInst          = quickfix.Tag(18)
 	TagSenderCompId      = quickfix.Tag(49)
 	TagSendingTime       = quickfix.Tag(52)
@@ -112,5 +133,62 @@ const (
 	TagExpireTime        = quickfix.Tag(126)
 	TagParticipationRate = quickfix.Tag(849)
 
+	TagAvgPx                = quickfix.Tag(6)
+	TagCumQty               = quickfix.Tag(14)
+	TagOrdStatus            = quickfix.Tag(39)
+	TagLeavesQty            = quickfix.Tag(151)
+	TagFilledAmount         = quickfix.Tag(8002)
+	TagNetAvgPrice          = quickfix.Tag(8006)
+	TagCxlRejReason         = quickfix.Tag(102)
+	TagCxlRejResponseTo     = quickfix.Tag(434)
+	TagMsgSeqNum            = quickfix.Tag(34)
+	TagPossDupFlag          = quickfix.Tag(43)
+	TagOrigSendingTime      = quickfix.Tag(122)
+	TagResetSeqNumFlag      = quickfix.Tag(141)
+	TagRefSeqNum            = quickfix.Tag(45)
+	TagRefTagId             = quickfix.Tag(371)
+	TagRefMsgType           = quickfix.Tag(372)
+	TagSessionRejectReason  = quickfix.Tag(373)
+	TagBusinessRejectRefId  = quickfix.Tag(379)
+	TagBusinessRejectReason = quickfix.Tag(380)
+	TagCommission           = quickfix.Tag(12)
+	TagExecId               = quickfix.Tag(17)
+	TagLastPx               = quickfix.Tag(31)
+	TagLastShares           = quickfix.Tag(32)
+	TagTransactTime         = quickfix.Tag(60)
+	TagLastLiquidityInd     = quickfix.Tag(851)
+
 	QuoteAckStatusRejected = "5"
+
+	ExecInstPostOnly = "A" // Add liquidity only
+
+	CxlRejResponseToCancel  = "1"
+	CxlRejResponseToReplace = "2"
+
+	OrdStatusNew             = "0"
+	OrdStatusPartiallyFilled = "1"
+	OrdStatusFilled          = "2"
+	OrdStatusDoneForDay      = "3"
+	OrdStatusCanceled        = "4"
+	OrdStatusReplaced        = "5"
+	OrdStatusPendingCancel   = "6"
+	OrdStatusRejected        = "8"
+	OrdStatusPendingNew      = "A"
+	OrdStatusExpired         = "C"
+	OrdStatusPendingReplace  = "E"
+
+	ExecTypeNew            = "0"
+	ExecTypePartialFill    = "1"
+	ExecTypeFill           = "2"
+	ExecTypeDoneForDay     = "3"
+	ExecTypeCanceled       = "4"
+	ExecTypeReplaced       = "5"
+	ExecTypePendingCancel  = "6"
+	ExecTypeRejected       = "8"
+	ExecTypePendingNew     = "A"
+	ExecTypeExpired        = "C"
+	ExecTypeRestated       = "D"
+	ExecTypeTrade          = "F"
+	ExecTypePendingReplace = "E"
+	ExecTypeOrderStatus    = "I"
 )
@@ -0,0 +1,184 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package credentials supplies the API credentials that sign the FIX Logon.
+// A Source is asked for them on every Logon, and the signing key it returns
+// is zeroed once the signature is computed, so sources that read files or
+// run a helper keep no secret in memory between logons.
+package credentials
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Credentials are the Prime API credentials. Only the signing key is held as
+// bytes that can be zeroed: the access key and passphrase are sent in the
+// Logon itself.
+type Credentials struct {
+	AccessKey  string
+	SigningKey []byte
+	Passphrase string
+}
+
+// Complete reports whether every credential is set.
+func (c *Credentials) Complete() bool {
+	return c.AccessKey != "" && len(c.SigningKey) > 0 && c.Passphrase != ""
+}
+
+// Zero overwrites the signing key and forgets the other credentials.
+func (c *Credentials) Zero() {
+	if c == nil {
+		return
+	}
+	clear(c.SigningKey)
+	c.SigningKey = nil
+	c.AccessKey = ""
+	c.Passphrase = ""
+}
+
+func (c *Credentials) clone() *Credentials {
+	return &Credentials{
+		AccessKey:  c.AccessKey,
+		SigningKey: bytes.Clone(c.SigningKey),
+		Passphrase: c.Passphrase,
+	}
+}
+
+// Source supplies credentials. Each call returns a new Credentials that the
+// caller zeroes when done; a source may leave some of them unset.
+type Source interface {
+	Fetch() (*Credentials, error)
+}
+
+// Static holds credentials given in the configuration or the environment.
+type Static struct {
+	AccessKey  string
+	SigningKey string
+	Passphrase string
+}
+
+func (s Static) Fetch() (*Credentials, error) {
+	c := &Credentials{AccessKey: s.AccessKey, Passphrase: s.Passphrase}
+	if s.SigningKey != "" {
+		c.SigningKey = []byte(s.SigningKey)
+	}
+	return c, nil
+}
+
+// Chain asks each source in turn for the credentials still missing; the
+// first source to supply a credential wins, and later sources are not asked
+// once all of them are set.
+type Chain []Source
+
+func (ch Chain) Fetch() (*Credentials, error) {
+	merged := &Credentials{}
+	for _, src := range ch {
+		if merged.Complete() {
+			break
+		}
+		c, err := src.Fetch()
+		if err != nil {
+			merged.Zero()
+			return nil, err
+		}
+		if merged.AccessKey == "" {
+			merged.AccessKey = c.AccessKey
+		}
+		if merged.Passphrase == "" {
+			merged.Passphrase = c.Passphrase
+		}
+		if len(merged.SigningKey) == 0 {
+			merged.SigningKey, c.SigningKey = c.SigningKey, nil
+		}
+		c.Zero()
+	}
+
+	var missing []string
+	for _, v := range []struct {
+		name string
+		set  bool
+	}{
+		{"access key", merged.AccessKey != ""},
+		{"signing key", len(merged.SigningKey) > 0},
+		{"passphrase", merged.Passphrase != ""},
+	} {
+		if !v.set {
+			missing = append(missing, v.name)
+		}
+	}
+	if len(missing) > 0 {
+		merged.Zero()
+		return nil, fmt.Errorf("no %s from any credential source", strings.Join(missing, ", "))
+	}
+	return merged, nil
+}
+
+// Close closes the sources that hold credentials, such as an unlocked
+// keyfile.
+func (ch Chain) Close() error {
+	var first error
+	for _, src := range ch {
+		if c, ok := src.(io.Closer); ok {
+			if err := c.Close(); err != nil && first == nil {
+				first = err
+			}
+		}
+	}
+	return first
+}
+
+// parseFields reads credentials written as key=value lines, the format of
+// both the credential helper's output and a decrypted keyfile. Unknown keys,
+// blank lines and # comments are ignored. The signing key is copied out of
+// data, so the caller can zero data afterwards.
+func parseFields(data []byte) *Credentials {
+	c := &Credentials{}
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+		key, value, ok := bytes.Cut(line, []byte("="))
+		if !ok {
+			continue
+		}
+		switch string(bytes.TrimSpace(key)) {
+		case "accessKey":
+			c.AccessKey = string(value)
+		case "signingKey":
+			c.SigningKey = bytes.Clone(value)
+		case "passphrase":
+			c.Passphrase = string(value)
+		}
+	}
+	return c
+}
+
+// formatFields writes credentials in the format parseFields reads. The
+// buffer is sized up front so no copy of the signing key is left behind by
+// growing it.
+func formatFields(c *Credentials) []byte {
+	var buf bytes.Buffer
+	buf.Grow(len(c.AccessKey) + len(c.SigningKey) + len(c.Passphrase) + 40)
+	buf.WriteString("accessKey=" + c.AccessKey + "\n")
+	buf.WriteString("signingKey=")
+	buf.Write(c.SigningKey)
+	buf.WriteString("\npassphrase=" + c.Passphrase + "\n")
+	return buf.Bytes()
+}
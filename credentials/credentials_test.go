@@ -0,0 +1,157 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package credentials
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func writeSecret(t *testing.T, name, content string, perm os.FileMode) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), perm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(path, perm); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFilesChecksPermissions(t *testing.T) {
+	key := writeSecret(t, "signing_key", "secret\n", 0o600)
+	c, err := Files{SigningKey: key}.Fetch()
+	if err != nil {
+		t.Fatalf("Fetch returned error: %v", err)
+	}
+	if string(c.SigningKey) != "secret" {
+		t.Errorf("Expected the trimmed file contents, got %q", c.SigningKey)
+	}
+	c.Zero()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("mode bits are not checked on Windows")
+	}
+	open := writeSecret(t, "passphrase", "pass", 0o644)
+	if _, err := (Files{Passphrase: open}).Fetch(); !errors.Is(err, ErrInsecure) {
+		t.Errorf("Expected ErrInsecure for a world-readable file, got %v", err)
+	}
+}
+
+func TestChainFillsMissingCredentials(t *testing.T) {
+	key := writeSecret(t, "signing_key", "from-file", 0o600)
+	chain := Chain{
+		Static{AccessKey: "key"},
+		Files{SigningKey: key},
+		Static{AccessKey: "other", SigningKey: "other", Passphrase: "pass"},
+	}
+	c, err := chain.Fetch()
+	if err != nil {
+		t.Fatalf("Fetch returned error: %v", err)
+	}
+	if c.AccessKey != "key" || string(c.SigningKey) != "from-file" || c.Passphrase != "pass" {
+		t.Errorf("Expected the first source of each credential to win, got %+v", c)
+	}
+
+	signingKey := c.SigningKey
+	c.Zero()
+	for _, b := range signingKey {
+		if b != 0 {
+			t.Fatalf("Expected Zero to overwrite the signing key, got %q", signingKey)
+		}
+	}
+
+	if _, err := (Chain{Static{AccessKey: "key"}}).Fetch(); err == nil {
+		t.Error("Expected an error for missing credentials")
+	}
+}
+
+func TestHelper(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("helpers run through sh")
+	}
+	script := writeSecret(t, "helper.sh", `#!/bin/sh
+[ "$1" = get ] || exit 1
+grep -q '^profile=sandbox$' || exit 2
+echo accessKey=key
+echo signingKey=secret
+echo passphrase=pass
+`, 0o700)
+	c, err := Helper{Command: script, Input: map[string]string{"profile": "sandbox"}}.Fetch()
+	if err != nil {
+		t.Fatalf("Fetch returned error: %v", err)
+	}
+	if c.AccessKey != "key" || string(c.SigningKey) != "secret" || c.Passphrase != "pass" {
+		t.Errorf("Unexpected credentials %+v", c)
+	}
+
+	if _, err := (Helper{Command: script, Input: map[string]string{"profile": "production"}}).Fetch(); err == nil {
+		t.Error("Expected an error when the helper fails")
+	}
+}
+
+func TestKeyfileRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	creds := &Credentials{AccessKey: "key", SigningKey: []byte("secret"), Passphrase: "pass"}
+	if err := WriteKeyfile(path, creds, []byte("correct horse")); err != nil {
+		t.Fatalf("WriteKeyfile returned error: %v", err)
+	}
+	if err := CheckFile(path); err != nil {
+		t.Errorf("Expected an owner-only keyfile, got %v", err)
+	}
+
+	if _, err := OpenKeyfile(path, []byte("wrong")); !errors.Is(err, ErrBadPassphrase) {
+		t.Errorf("Expected ErrBadPassphrase, got %v", err)
+	}
+	kf, err := OpenKeyfile(path, []byte("correct horse"))
+	if err != nil {
+		t.Fatalf("OpenKeyfile returned error: %v", err)
+	}
+	c, err := kf.Fetch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.AccessKey != "key" || string(c.SigningKey) != "secret" || c.Passphrase != "pass" {
+		t.Errorf("Unexpected credentials %+v", c)
+	}
+	kf.Close()
+	if _, err := kf.Fetch(); err == nil {
+		t.Error("Expected an error from a closed keyfile")
+	}
+}
+
+func TestPbkdf2(t *testing.T) {
+	// RFC 7914, section 11.
+	want := "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc" +
+		"49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783"
+	got := hex.EncodeToString(pbkdf2(sha256.New, []byte("passwd"), []byte("salt"), 1, 64))
+	if got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+	want = "4ddcd8f60b98be21830cee5ef22701f9641a4418d04c0414aeff08876b34ab56" +
+		"a1d425a1225833549adb841b51c9b3176a272bdebba1d078478f62b397f33c8d"
+	got = hex.EncodeToString(pbkdf2(sha256.New, []byte("Password"), []byte("NaCl"), 80000, 64))
+	if got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
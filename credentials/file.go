@@ -0,0 +1,103 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package credentials
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+)
+
+// ErrInsecure is returned for a secret file that other users can read or
+// write.
+var ErrInsecure = errors.New("is accessible by other users")
+
+// CheckFile makes sure path is a regular file only its owner can access.
+// Permissions are not checked on Windows, where the mode bits do not
+// reflect the file's ACL.
+func CheckFile(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("%s is not a regular file", path)
+	}
+	if perm := info.Mode().Perm(); runtime.GOOS != "windows" && perm&0o077 != 0 {
+		return fmt.Errorf("%s %w (mode %04o); restrict it with chmod 600", path, ErrInsecure, perm)
+	}
+	return nil
+}
+
+// Files reads each credential from its own file, as named by the ACCESS_KEY_FILE,
+// SIGNING_KEY_FILE and PASSPHRASE_FILE variables. Surrounding whitespace,
+// such as a trailing newline, is dropped. Files are read again for every
+// Logon, so a rotated secret is picked up on the next reconnect.
+type Files struct {
+	AccessKey  string
+	SigningKey string
+	Passphrase string
+}
+
+func (f Files) Fetch() (*Credentials, error) {
+	c := &Credentials{}
+	if f.AccessKey != "" {
+		v, err := readSecret(f.AccessKey)
+		if err != nil {
+			return nil, err
+		}
+		c.AccessKey = string(v)
+		clear(v)
+	}
+	if f.SigningKey != "" {
+		v, err := readSecret(f.SigningKey)
+		if err != nil {
+			return nil, err
+		}
+		c.SigningKey = v
+	}
+	if f.Passphrase != "" {
+		v, err := readSecret(f.Passphrase)
+		if err != nil {
+			c.Zero()
+			return nil, err
+		}
+		c.Passphrase = string(v)
+		clear(v)
+	}
+	return c, nil
+}
+
+// readSecret reads a secret file after checking its permissions. The
+// returned slice is a copy; the file contents are zeroed.
+func readSecret(path string) ([]byte, error) {
+	if err := CheckFile(path); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(data)
+	v := bytes.TrimSpace(data)
+	if len(v) == 0 {
+		return nil, fmt.Errorf("%s is empty", path)
+	}
+	return bytes.Clone(v), nil
+}
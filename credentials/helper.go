@@ -0,0 +1,79 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package credentials
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"time"
+)
+
+// DefaultHelperTimeout bounds how long a credential helper may run.
+const DefaultHelperTimeout = 30 * time.Second
+
+// Helper runs an external command for the credentials, much like a git
+// credential helper. The command is run through sh with "get" appended, is
+// given Input as key=value lines on stdin, and prints the credentials on
+// stdout as accessKey=, signingKey= and passphrase= lines. Its stderr goes to
+// the client's, so it can report errors or prompt on the terminal. It is run
+// again for every Logon.
+type Helper struct {
+	Command string
+	Input   map[string]string
+	Timeout time.Duration
+}
+
+func (h Helper) Fetch() (*Credentials, error) {
+	timeout := h.Timeout
+	if timeout <= 0 {
+		timeout = DefaultHelperTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	keys := make([]string, 0, len(h.Input))
+	for k := range h.Input {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var in bytes.Buffer
+	for _, k := range keys {
+		fmt.Fprintf(&in, "%s=%s\n", k, h.Input[k])
+	}
+
+	var out bytes.Buffer
+	// Room for any usual output, so growing the buffer leaves no stray copy
+	// of the secrets behind.
+	out.Grow(4096)
+	cmd := exec.CommandContext(ctx, "sh", "-c", h.Command+" get")
+	cmd.Stdin = &in
+	cmd.Stdout = &out
+	cmd.Stderr = os.Stderr
+	err := cmd.Run()
+	defer clear(out.Bytes())
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("credential helper %q: timed out after %s", h.Command, timeout)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("credential helper %q: %w", h.Command, err)
+	}
+	return parseFields(out.Bytes()), nil
+}
@@ -0,0 +1,196 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package credentials
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"os"
+)
+
+const (
+	keyfileVersion = 1
+	keyfileKdf     = "pbkdf2-sha256"
+	// keyfileIterations follows the OWASP recommendation for PBKDF2-SHA256.
+	keyfileIterations = 600000
+)
+
+// ErrBadPassphrase is returned when a keyfile does not decrypt.
+var ErrBadPassphrase = errors.New("wrong passphrase or damaged keyfile")
+
+// keyfileData is the on-disk layout of an encrypted keyfile: the
+// credentials as key=value lines, sealed with AES-256-GCM under a key
+// derived from the passphrase with PBKDF2.
+type keyfileData struct {
+	Version    int    `json:"version"`
+	Kdf        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Keyfile holds credentials decrypted from a keyfile. Since the passphrase
+// is only asked for once, at startup, the credentials stay in memory for
+// reconnects until Close zeroes them.
+type Keyfile struct {
+	creds *Credentials
+}
+
+// OpenKeyfile decrypts the keyfile at path with passphrase.
+func OpenKeyfile(path string, passphrase []byte) (*Keyfile, error) {
+	if err := CheckFile(path); err != nil {
+		return nil, err
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var kf keyfileData
+	if err := json.Unmarshal(raw, &kf); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if kf.Version != keyfileVersion || kf.Kdf != keyfileKdf || kf.Iterations <= 0 {
+		return nil, fmt.Errorf("%s: unsupported keyfile version %d (%s)", path, kf.Version, kf.Kdf)
+	}
+
+	aead, err := keyfileCipher(passphrase, kf.Salt, kf.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	if len(kf.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("%s: %w", path, ErrBadPassphrase)
+	}
+	plain, err := aead.Open(nil, kf.Nonce, kf.Ciphertext, keyfileAad())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, ErrBadPassphrase)
+	}
+	defer clear(plain)
+	return &Keyfile{creds: parseFields(plain)}, nil
+}
+
+func (k *Keyfile) Fetch() (*Credentials, error) {
+	if k.creds == nil {
+		return nil, errors.New("keyfile is closed")
+	}
+	return k.creds.clone(), nil
+}
+
+// Close zeroes the decrypted credentials.
+func (k *Keyfile) Close() error {
+	k.creds.Zero()
+	k.creds = nil
+	return nil
+}
+
+// WriteKeyfile encrypts creds with passphrase into a new keyfile at path,
+// readable only by its owner.
+func WriteKeyfile(path string, creds *Credentials, passphrase []byte) error {
+	kf := keyfileData{
+		Version:    keyfileVersion,
+		Kdf:        keyfileKdf,
+		Iterations: keyfileIterations,
+		Salt:       make([]byte, 16),
+	}
+	if _, err := rand.Read(kf.Salt); err != nil {
+		return err
+	}
+	aead, err := keyfileCipher(passphrase, kf.Salt, kf.Iterations)
+	if err != nil {
+		return err
+	}
+	kf.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(kf.Nonce); err != nil {
+		return err
+	}
+	plain := formatFields(creds)
+	kf.Ciphertext = aead.Seal(nil, kf.Nonce, plain, keyfileAad())
+	clear(plain)
+
+	data, err := json.MarshalIndent(kf, "", "  ")
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	// An existing file keeps its mode when truncated.
+	if err := f.Chmod(0o600); err != nil {
+		f.Close()
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// keyfileAad binds the ciphertext to the format, so it cannot be reused
+// under another version's header.
+func keyfileAad() []byte {
+	return []byte(fmt.Sprintf("prime-fix-go keyfile v%d %s", keyfileVersion, keyfileKdf))
+}
+
+func keyfileCipher(passphrase, salt []byte, iterations int) (cipher.AEAD, error) {
+	key := pbkdf2(sha256.New, passphrase, salt, iterations, 32)
+	defer clear(key)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// pbkdf2 derives a key of keyLen bytes from password as in RFC 8018.
+func pbkdf2(h func() hash.Hash, password, salt []byte, iterations, keyLen int) []byte {
+	prf := hmac.New(h, password)
+	size := prf.Size()
+	key := make([]byte, 0, (keyLen+size-1)/size*size)
+	u := make([]byte, 0, size)
+	t := make([]byte, size)
+	var counter [4]byte
+	for block := uint32(1); len(key) < keyLen; block++ {
+		binary.BigEndian.PutUint32(counter[:], block)
+		prf.Reset()
+		prf.Write(salt)
+		prf.Write(counter[:])
+		u = prf.Sum(u[:0])
+		copy(t, u)
+		for i := 1; i < iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		key = append(key, t...)
+	}
+	clear(u)
+	clear(t)
+	return key[:keyLen]
+}
@@ -0,0 +1,85 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package credentials
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// ErrNoTerminal is returned by ReadPassphrase when there is no terminal to
+// prompt on, as when running as a service.
+var ErrNoTerminal = errors.New("no terminal to prompt for a passphrase")
+
+// ReadPassphrase prompts on the controlling terminal and reads a line with
+// echo turned off, using stty. The caller zeroes the result.
+func ReadPassphrase(prompt string) ([]byte, error) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNoTerminal, err)
+	}
+	defer tty.Close()
+
+	fmt.Fprint(tty, prompt)
+	if err := stty(tty, "-echo"); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNoTerminal, err)
+	}
+	defer func() {
+		stty(tty, "echo")
+		fmt.Fprintln(tty)
+	}()
+
+	// Read a byte at a time, so the passphrase is never left in a buffer
+	// that cannot be zeroed.
+	var line []byte
+	b := make([]byte, 1)
+	for {
+		n, err := tty.Read(b)
+		if n == 1 {
+			if b[0] == '\n' {
+				break
+			}
+			if b[0] != '\r' {
+				line = appendSecret(line, b[0])
+			}
+		}
+		if err != nil {
+			clear(line)
+			return nil, err
+		}
+	}
+	return line, nil
+}
+
+// appendSecret appends c to line, zeroing the old array when it grows.
+func appendSecret(line []byte, c byte) []byte {
+	if len(line) < cap(line) {
+		return append(line, c)
+	}
+	grown := make([]byte, len(line), 2*cap(line)+16)
+	copy(grown, line)
+	clear(line)
+	return append(grown, c)
+}
+
+func stty(tty *os.File, arg string) error {
+	cmd := exec.Command("stty", arg)
+	cmd.Stdin = tty
+	return cmd.Run()
+}
@@ -0,0 +1,251 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package events publishes order, fill, quote and session events to local
+// subscribers and keeps a bounded history so a reconnecting subscriber can
+// replay what it missed.
+package events
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Event types.
+const (
+	TypeOrder    = "order"
+	TypeFill     = "fill"
+	TypeQuote    = "quote"
+	TypeQuoteAck = "quote_ack"
+	TypeReject   = "reject"
+	TypeSession  = "session"
+)
+
+// Types lists every event type a subscriber can filter on.
+var Types = []string{TypeOrder, TypeFill, TypeQuote, TypeQuoteAck, TypeReject, TypeSession}
+
+// DefaultHistory is the number of events a Bus retains for replay.
+const DefaultHistory = 10000
+
+// subscriberBuffer is how many events a subscriber may fall behind before it
+// is dropped.
+const subscriberBuffer = 256
+
+// Event is one published event. Seq increases by one with every event
+// published on a Bus, starting at 1. Epoch identifies the Bus, and so the
+// run of the client, that numbered it.
+type Event struct {
+	Epoch  string    `json:"epoch"`
+	Seq    uint64    `json:"seq"`
+	Type   string    `json:"type"`
+	Time   time.Time `json:"time"`
+	Symbol string    `json:"symbol,omitempty"`
+	Data   any       `json:"data"`
+}
+
+// QuoteAck is the data of a quote_ack event.
+type QuoteAck struct {
+	QuoteReqId   string `json:"quoteReqId"`
+	Status       string `json:"status"`
+	RejectReason string `json:"rejectReason,omitempty"`
+	Text         string `json:"text,omitempty"`
+}
+
+// Reject is the data of a reject event. Source is the FIX message that
+// carried the reject: "session", "business" or "cancel".
+type Reject struct {
+	Source     string `json:"source"`
+	RefMsgType string `json:"refMsgType,omitempty"`
+	ClOrdId    string `json:"clOrdId,omitempty"`
+	QuoteReqId string `json:"quoteReqId,omitempty"`
+	Reason     string `json:"reason"`
+}
+
+// Session is the data of a session event. Status is "logon" or "logout".
+type Session struct {
+	Status    string `json:"status"`
+	SessionId string `json:"sessionId"`
+}
+
+// Filter selects the events a subscriber receives. An empty list matches
+// everything; symbols match case-insensitively.
+type Filter struct {
+	Symbols []string
+	Types   []string
+}
+
+// Match reports whether e passes the filter. Events without a symbol, such as
+// session events, pass any symbol filter.
+func (f Filter) Match(e Event) bool {
+	if len(f.Types) > 0 && !contains(f.Types, e.Type, false) {
+		return false
+	}
+	if len(f.Symbols) > 0 && e.Symbol != "" && !contains(f.Symbols, e.Symbol, true) {
+		return false
+	}
+	return true
+}
+
+func contains(values []string, s string, fold bool) bool {
+	for _, v := range values {
+		if v == s || (fold && strings.EqualFold(v, s)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Subscription receives the events published after it was created. Events is
+// closed when the subscription is cancelled or falls too far behind.
+type Subscription struct {
+	Events <-chan Event
+	// Replay holds the retained events from the requested sequence onwards
+	// that match the filter, oldest first.
+	Replay []Event
+	// Missed counts events from the requested sequence onwards that were no
+	// longer retained.
+	Missed uint64
+	// Reset is set when the requested sequence belongs to another epoch or
+	// lies beyond the last published event, as when the client restarted and
+	// its numbering began again. Replay then starts at the oldest retained
+	// event.
+	Reset bool
+
+	bus     *Bus
+	ch      chan Event
+	filter  Filter
+	dropped bool
+}
+
+// Dropped reports whether Events was closed because the subscriber fell
+// behind. It is only meaningful once Events is closed.
+func (s *Subscription) Dropped() bool {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	return s.dropped
+}
+
+// Close cancels the subscription.
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	s.bus.removeLocked(s)
+}
+
+// Bus fans events out to subscribers and retains the most recent ones.
+// Publishing never blocks.
+type Bus struct {
+	mu      sync.Mutex
+	epoch   string
+	seq     uint64
+	history []Event
+	limit   int
+	subs    map[*Subscription]struct{}
+}
+
+// NewBus returns a bus retaining up to history events for replay.
+func NewBus(history int) *Bus {
+	if history <= 0 {
+		history = DefaultHistory
+	}
+	return &Bus{
+		epoch: strconv.FormatInt(time.Now().UnixNano(), 36),
+		limit: history,
+		subs:  make(map[*Subscription]struct{}),
+	}
+}
+
+// Epoch returns the identifier stamped on every event of this bus.
+func (b *Bus) Epoch() string {
+	return b.epoch
+}
+
+// Publish assigns the next sequence number to an event and delivers it to
+// every matching subscriber. A subscriber whose buffer is full is dropped.
+func (b *Bus) Publish(typ, symbol string, data any) Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.seq++
+	e := Event{Epoch: b.epoch, Seq: b.seq, Type: typ, Time: time.Now().UTC(), Symbol: symbol, Data: data}
+	if len(b.history) == b.limit {
+		b.history = b.history[1:]
+	}
+	b.history = append(b.history, e)
+
+	for s := range b.subs {
+		if !s.filter.Match(e) {
+			continue
+		}
+		select {
+		case s.ch <- e:
+		default:
+			s.dropped = true
+			b.removeLocked(s)
+		}
+	}
+	return e
+}
+
+// Seq returns the sequence number of the last published event.
+func (b *Bus) Seq() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.seq
+}
+
+// Subscribe registers a subscriber for events matching filter. When from is
+// non-zero, retained events with a sequence number of at least from are
+// returned in Replay; no event is both replayed and delivered on Events.
+func (b *Bus) Subscribe(filter Filter, from uint64) *Subscription {
+	return b.Resume(filter, "", from)
+}
+
+// Resume is Subscribe for a subscriber that last saw event from-1 of epoch.
+// An empty epoch is taken to be this bus's.
+func (b *Bus) Resume(filter Filter, epoch string, from uint64) *Subscription {
+	ch := make(chan Event, subscriberBuffer)
+	s := &Subscription{Events: ch, bus: b, ch: ch, filter: filter}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if from > 0 && ((epoch != "" && epoch != b.epoch) || from > b.seq+1) {
+		s.Reset = true
+		from = 1
+	}
+	if from > 0 && from <= b.seq {
+		if len(b.history) > 0 && from < b.history[0].Seq {
+			s.Missed = b.history[0].Seq - from
+		} else if len(b.history) == 0 {
+			s.Missed = b.seq - from + 1
+		}
+		for _, e := range b.history {
+			if e.Seq >= from && filter.Match(e) {
+				s.Replay = append(s.Replay, e)
+			}
+		}
+	}
+	b.subs[s] = struct{}{}
+	return s
+}
+
+func (b *Bus) removeLocked(s *Subscription) {
+	if _, ok := b.subs[s]; ok {
+		delete(b.subs, s)
+		close(s.ch)
+	}
+}
@@ -0,0 +1,121 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import "testing"
+
+func TestSubscribeFiltersBySymbolAndType(t *testing.T) {
+	bus := NewBus(10)
+	sub := bus.Subscribe(Filter{Symbols: []string{"btc-usd"}, Types: []string{TypeFill, TypeSession}}, 0)
+	defer sub.Close()
+
+	bus.Publish(TypeFill, "ETH-USD", nil)
+	bus.Publish(TypeOrder, "BTC-USD", nil)
+	bus.Publish(TypeFill, "BTC-USD", nil)
+	bus.Publish(TypeSession, "", Session{Status: "logout"})
+
+	for _, want := range []uint64{3, 4} {
+		if e := <-sub.Events; e.Seq != want {
+			t.Errorf("Expected event %d, got %d (%s %s)", want, e.Seq, e.Type, e.Symbol)
+		}
+	}
+	if len(sub.Events) != 0 {
+		t.Errorf("Expected no further events, got %d", len(sub.Events))
+	}
+}
+
+func TestSubscribeReplaysFromSequence(t *testing.T) {
+	bus := NewBus(10)
+	for i := 0; i < 5; i++ {
+		bus.Publish(TypeOrder, "BTC-USD", nil)
+	}
+	sub := bus.Subscribe(Filter{}, 3)
+	defer sub.Close()
+	bus.Publish(TypeOrder, "BTC-USD", nil)
+
+	if len(sub.Replay) != 3 || sub.Replay[0].Seq != 3 || sub.Replay[2].Seq != 5 {
+		t.Fatalf("Expected events 3-5 replayed, got %v", sub.Replay)
+	}
+	if sub.Missed != 0 {
+		t.Errorf("Expected nothing missed, got %d", sub.Missed)
+	}
+	if e := <-sub.Events; e.Seq != 6 {
+		t.Errorf("Expected event 6 live, got %d", e.Seq)
+	}
+}
+
+func TestSubscribeReportsEventsNoLongerRetained(t *testing.T) {
+	bus := NewBus(3)
+	for i := 0; i < 6; i++ {
+		bus.Publish(TypeOrder, "BTC-USD", nil)
+	}
+	sub := bus.Subscribe(Filter{}, 2)
+	defer sub.Close()
+	if sub.Missed != 2 {
+		t.Errorf("Expected events 2-3 missed, got %d", sub.Missed)
+	}
+	if len(sub.Replay) != 3 || sub.Replay[0].Seq != 4 {
+		t.Errorf("Expected events 4-6 replayed, got %v", sub.Replay)
+	}
+}
+
+func TestResumeDetectsRestart(t *testing.T) {
+	bus := NewBus(3)
+	for i := 0; i < 4; i++ {
+		bus.Publish(TypeOrder, "BTC-USD", nil)
+	}
+
+	sub := bus.Resume(Filter{}, bus.Epoch(), 5)
+	sub.Close()
+	if sub.Reset || len(sub.Replay) != 0 {
+		t.Errorf("Expected one past the last event to resume live, got reset=%v %v", sub.Reset, sub.Replay)
+	}
+
+	for _, tt := range []struct {
+		name  string
+		epoch string
+		from  uint64
+	}{
+		{"sequence ahead of the bus", "", 100},
+		{"earlier epoch", "earlier", 2},
+	} {
+		sub := bus.Resume(Filter{}, tt.epoch, tt.from)
+		sub.Close()
+		if !sub.Reset {
+			t.Errorf("%s: expected a reset", tt.name)
+		}
+		if sub.Missed != 1 || len(sub.Replay) != 3 || sub.Replay[0].Seq != 2 {
+			t.Errorf("%s: expected events 2-4 replayed and 1 missed, got %d missed, %v", tt.name, sub.Missed, sub.Replay)
+		}
+	}
+}
+
+func TestSlowSubscriberIsDropped(t *testing.T) {
+	bus := NewBus(10)
+	sub := bus.Subscribe(Filter{}, 0)
+	for i := 0; i < subscriberBuffer+1; i++ {
+		bus.Publish(TypeOrder, "BTC-USD", nil)
+	}
+	n := 0
+	for range sub.Events {
+		n++
+	}
+	if n != subscriberBuffer || !sub.Dropped() {
+		t.Errorf("Expected the subscriber dropped after %d events, got %d (dropped %v)", subscriberBuffer, n, sub.Dropped())
+	}
+	sub.Close()
+}
@@ -0,0 +1,156 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fixclient
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"prime-fix-go/amount"
+	"prime-fix-go/constants"
+	"prime-fix-go/events"
+	"prime-fix-go/model"
+	"prime-fix-go/utils"
+
+	"github.com/quickfixgo/quickfix"
+)
+
+// fillFromExecReport returns the execution carried by a trade ExecutionReport,
+// or false when the report does not represent a fill.
+func fillFromExecReport(msg *quickfix.Message) (model.Fill, bool) {
+	switch utils.GetString(msg, constants.TagExecType) {
+	case constants.ExecTypePartialFill, constants.ExecTypeFill, constants.ExecTypeTrade:
+	default:
+		return model.Fill{}, false
+	}
+	fill := model.Fill{
+		ExecId:             utils.GetString(msg, constants.TagExecId),
+		ClOrdId:            utils.GetString(msg, constants.TagClOrdId),
+		OrderId:            utils.GetString(msg, constants.TagOrderId),
+		Account:            utils.GetString(msg, constants.TagAccount),
+		Side:               utils.GetString(msg, constants.TagSide),
+		Symbol:             utils.GetString(msg, constants.TagSymbol),
+		LastPx:             utils.GetString(msg, constants.TagLastPx),
+		LastShares:         utils.GetString(msg, constants.TagLastShares),
+		TransactTime:       utils.GetString(msg, constants.TagTransactTime),
+		Commission:         utils.GetString(msg, constants.TagCommission),
+		LiquidityIndicator: utils.GetString(msg, constants.TagLastLiquidityInd),
+	}
+	if fill.ExecId == "" {
+		return model.Fill{}, false
+	}
+	if _, err := amount.Positive(fill.LastShares); err != nil {
+		return model.Fill{}, false
+	}
+	if fill.TransactTime == "" {
+		fill.TransactTime = time.Now().UTC().Format(constants.FixTimeFormat)
+	}
+	return fill, true
+}
+
+// recordFillLocked appends fill to the ledger unless its ExecID has been seen,
+// which happens when Prime resends ExecutionReports after a reconnect. The
+// caller must hold a.mu.
+func (a *FixApp) recordFillLocked(fill model.Fill) bool {
+	if _, seen := a.fillIds[fill.ExecId]; seen {
+		return false
+	}
+	a.fills = append(a.fills, fill)
+	a.fillIds[fill.ExecId] = struct{}{}
+	if err := a.store.SaveFill(fill); err != nil {
+		log.Println("fill ledger save err:", err)
+	}
+	a.events.Publish(events.TypeFill, fill.Symbol, fill)
+	return true
+}
+
+// applyFillLocked logs a newly recorded fill and applies it to positions. The
+// caller must hold a.mu.
+func (a *FixApp) applyFillLocked(fill model.Fill) {
+	log.Printf("⇡ fill %s: %s %s %s @ %s", fill.ExecId, sideName(fill.Side), fill.LastShares, fill.Symbol, fill.LastPx)
+	if err := a.positions.Apply(fill, a.config.PortfolioId); err != nil {
+		log.Println("position err:", err)
+	}
+}
+
+func (a *FixApp) handleFills(parts []string) {
+	var symbol string
+	var since time.Time
+	for _, arg := range parts[1:] {
+		if t, ok := ParseSince(arg); ok {
+			since = t
+		} else {
+			symbol = arg
+		}
+	}
+
+	fills := a.Fills(symbol, since)
+	if len(fills) == 0 {
+		fmt.Fprintln(a.out, "(no fills)")
+		return
+	}
+	for _, f := range fills {
+		fmt.Fprintf(a.out, "%-21s %-20s %-4s %-10s %s @ %s", f.TransactTime, f.ClOrdId, sideName(f.Side), f.Symbol, f.LastShares, f.LastPx)
+		if f.Commission != "" {
+			fmt.Fprintf(a.out, " fee %s", f.Commission)
+		}
+		if f.LiquidityIndicator != "" {
+			fmt.Fprintf(a.out, " liq %s", f.LiquidityIndicator)
+		}
+		fmt.Fprintf(a.out, " (exec %s)\n", f.ExecId)
+	}
+	fmt.Fprintf(a.out, "%d fill(s)\n", len(fills))
+}
+
+// ParseSince accepts "today", a duration such as "2h" meaning that long ago,
+// a date, or an RFC 3339 timestamp.
+func ParseSince(arg string) (time.Time, bool) {
+	if strings.EqualFold(arg, "today") {
+		now := time.Now().UTC()
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), true
+	}
+	if d, err := time.ParseDuration(arg); err == nil {
+		return time.Now().Add(-d), true
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, arg); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func parseFixTime(value string) (time.Time, bool) {
+	for _, layout := range []string{constants.FixTimeFormat, "20060102-15:04:05"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func sideName(side string) string {
+	switch side {
+	case constants.SideBuyFix:
+		return constants.SideBuy
+	case constants.SideSellFix:
+		return constants.SideSell
+	}
+	return side
+}
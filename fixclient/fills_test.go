@@ -0,0 +1,140 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fixclient
+
+import (
+	"testing"
+	"time"
+
+	"prime-fix-go/constants"
+	"prime-fix-go/model"
+
+	"github.com/quickfixgo/quickfix"
+)
+
+func partialFill(clOrdId, execId, transactTime string) *quickfix.Message {
+	return execReport(clOrdId, constants.ExecTypePartialFill, constants.OrdStatusPartiallyFilled, map[quickfix.Tag]string{
+		constants.TagExecId:       execId,
+		constants.TagLastShares:   "0.25",
+		constants.TagLastPx:       "50000",
+		constants.TagCumQty:       "0.25",
+		constants.TagLeavesQty:    "0.75",
+		constants.TagTransactTime: transactTime,
+	})
+}
+
+func TestResentFillIsRecordedOnce(t *testing.T) {
+	app := newTestApp(t)
+	clOrdId := placeWorking(t, app)
+
+	deliver(app, partialFill(clOrdId, "exec-1", "20250102-10:00:00.000"))
+	resent := partialFill(clOrdId, "exec-1", "20250102-10:00:00.000")
+	resent.Header.SetString(constants.TagPossDupFlag, "Y")
+	deliver(app, resent)
+	deliver(app, partialFill(clOrdId, "exec-1", "20250102-10:00:00.000"))
+
+	if fills := app.Fills("", time.Time{}); len(fills) != 1 {
+		t.Errorf("Expected 1 fill, got %d", len(fills))
+	}
+	if saved, _ := app.store.LoadFills(); len(saved) != 1 {
+		t.Errorf("Expected 1 saved fill, got %d", len(saved))
+	}
+	if info, _ := app.Order(clOrdId); info.Status != model.OrderStatusPartiallyFilled || info.CumQty != "0.25" {
+		t.Errorf("Expected %s with 0.25 filled, got %s with %s", model.OrderStatusPartiallyFilled, info.Status, info.CumQty)
+	}
+}
+
+func TestFillsFiltersBySymbolAndTime(t *testing.T) {
+	app := newTestApp(t)
+	clOrdId := placeWorking(t, app)
+	deliver(app, partialFill(clOrdId, "exec-1", "20250102-10:00:00.000"))
+	deliver(app, partialFill(clOrdId, "exec-2", "20250103-10:00:00.000"))
+
+	since, _ := ParseSince("2025-01-03")
+	tests := []struct {
+		symbol string
+		since  time.Time
+		want   int
+	}{
+		{"", time.Time{}, 2},
+		{"btc-usd", time.Time{}, 2},
+		{"ETH-USD", time.Time{}, 0},
+		{"", since, 1},
+	}
+	for _, tt := range tests {
+		if fills := app.Fills(tt.symbol, tt.since); len(fills) != tt.want {
+			t.Errorf("Fills(%q, %v): expected %d, got %d", tt.symbol, tt.since, tt.want, len(fills))
+		}
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		arg  string
+		ok   bool
+		want time.Time
+	}{
+		{"today", true, time.Date(now.UTC().Year(), now.UTC().Month(), now.UTC().Day(), 0, 0, 0, 0, time.UTC)},
+		{"2025-01-02", true, time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"2025-01-02T10:30:00Z", true, time.Date(2025, 1, 2, 10, 30, 0, 0, time.UTC)},
+		{"BTC-USD", false, time.Time{}},
+	}
+	for _, tt := range tests {
+		got, ok := ParseSince(tt.arg)
+		if ok != tt.ok || !got.Equal(tt.want) {
+			t.Errorf("ParseSince(%q): expected %v %v, got %v %v", tt.arg, tt.want, tt.ok, got, ok)
+		}
+	}
+
+	got, ok := ParseSince("2h")
+	if want := now.Add(-2 * time.Hour); !ok || got.Sub(want).Abs() > time.Minute {
+		t.Errorf("ParseSince(\"2h\"): expected about %v, got %v %v", want, got, ok)
+	}
+}
+
+func TestResentPartialFillDoesNotRewindFilledOrder(t *testing.T) {
+	app := newTestApp(t)
+	clOrdId := placeWorking(t, app)
+	deliver(app, partialFill(clOrdId, "exec-1", "20250102-10:00:00.000"))
+	deliver(app, execReport(clOrdId, constants.ExecTypeFill, constants.OrdStatusFilled, map[quickfix.Tag]string{
+		constants.TagExecId:       "exec-2",
+		constants.TagLastShares:   "0.75",
+		constants.TagLastPx:       "50000",
+		constants.TagCumQty:       "1",
+		constants.TagLeavesQty:    "0",
+		constants.TagAvgPx:        "50000",
+		constants.TagTransactTime: "20250102-10:01:00.000",
+	}))
+	filled, _ := app.Order(clOrdId)
+
+	resent := partialFill(clOrdId, "exec-1", "20250102-10:00:00.000")
+	resent.Header.SetString(constants.TagPossDupFlag, "Y")
+	resent.Body.SetString(constants.TagText, "resent")
+	deliver(app, resent)
+
+	info, _ := app.Order(clOrdId)
+	if info.Status != model.OrderStatusFilled || info.CumQty != "1" || info.LeavesQty != "0" || info.AvgPx != "50000" || info.Text != filled.Text {
+		t.Errorf("Expected the filled order unchanged, got %s cum %s leaves %s avg %s text %q", info.Status, info.CumQty, info.LeavesQty, info.AvgPx, info.Text)
+	}
+	if info.IllegalTransition != "" {
+		t.Errorf("Expected no illegal transition, got %q", info.IllegalTransition)
+	}
+	if fills := app.Fills("", time.Time{}); len(fills) != 2 {
+		t.Errorf("Expected 2 fills, got %d", len(fills))
+	}
+}
@@ -17,85 +17,216 @@
 package fixclient
 
 import (
-	"bufio"
-	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"prime-fix-go/amount"
 	"prime-fix-go/builder"
 	"prime-fix-go/constants"
+	"prime-fix-go/credentials"
+	"prime-fix-go/events"
+	"prime-fix-go/instruments"
 	"prime-fix-go/model"
+	"prime-fix-go/positions"
+	"prime-fix-go/quotepolicy"
+	"prime-fix-go/risk"
+	"prime-fix-go/store"
 	"prime-fix-go/utils"
 
 	"github.com/quickfixgo/quickfix"
 )
 
-const orderFile = "orders.json"
-
 type FixApp struct {
-	SessionId quickfix.SessionID
-	orders    map[string]model.OrderInfo
-	config    *constants.Config
-	mu        sync.RWMutex
+	SessionId   quickfix.SessionID
+	orders      map[string]model.OrderInfo
+	fills       []model.Fill
+	fillIds     map[string]struct{}
+	positions   *positions.Tracker
+	risk        *risk.Engine
+	instruments *instruments.Catalog
+	events      *events.Bus
+	quotePolicy *quotepolicy.Policy
+	credentials credentials.Source
+	quotes      map[string]model.QuoteInfo
+	rfqs        map[string]model.QuoteRequestInfo
+	outbound    map[int]outboundRef
+	store       store.OrderStore
+	config      *constants.Config
+	out         io.Writer
+	loggedOn    atomic.Bool
+	mu          sync.RWMutex
 }
 
-func NewFixApp(config *constants.Config) *FixApp {
+func NewFixApp(config *constants.Config, orderStore store.OrderStore) *FixApp {
 	return &FixApp{
 		SessionId: quickfix.SessionID{},
 		orders:    make(map[string]model.OrderInfo),
+		fillIds:   make(map[string]struct{}),
+		positions: positions.NewTracker(),
+		events:    events.NewBus(events.DefaultHistory),
+		quotes:    make(map[string]model.QuoteInfo),
+		rfqs:      make(map[string]model.QuoteRequestInfo),
+		outbound:  make(map[int]outboundRef),
+		store:     orderStore,
 		config:    config,
+		out:       os.Stdout,
 	}
 }
 
+// SetOutput directs the client's console output, which is command results
+// and notifications, to w instead of stdout.
+func (a *FixApp) SetOutput(w io.Writer) {
+	a.out = w
+}
+
+// Events returns the bus on which order, fill, quote, reject and session
+// events are published.
+func (a *FixApp) Events() *events.Bus {
+	return a.events
+}
+
+// SetCredentials installs the source of the credentials that sign each
+// Logon.
+func (a *FixApp) SetCredentials(source credentials.Source) {
+	a.credentials = source
+}
+
+// LoggedOn reports whether the FIX session is logged on.
+func (a *FixApp) LoggedOn() bool {
+	return a.loggedOn.Load()
+}
+
 func (a *FixApp) OnCreate(sid quickfix.SessionID) {
 	a.SessionId = sid
 }
 
 func (a *FixApp) OnLogout(sid quickfix.SessionID) {
 	log.Println("Logout", sid)
+	a.loggedOn.Store(false)
+	a.events.Publish(events.TypeSession, "", events.Session{Status: "logout", SessionId: sid.String()})
 }
 
-func (a *FixApp) FromAdmin(_ *quickfix.Message, _ quickfix.SessionID) quickfix.MessageRejectError {
+func (a *FixApp) FromAdmin(msg *quickfix.Message, _ quickfix.SessionID) quickfix.MessageRejectError {
+	if t, _ := msg.Header.GetString(constants.TagMsgType); t == constants.MsgTypeReject {
+		a.handleSessionReject(msg)
+	}
 	return nil
 }
 
-func (a *FixApp) ToApp(_ *quickfix.Message, _ quickfix.SessionID) error {
+func (a *FixApp) ToApp(msg *quickfix.Message, _ quickfix.SessionID) error {
+	if isPossDup(msg) && a.staleResend(msg) {
+		return quickfix.ErrDoNotSend
+	}
+	a.recordOutbound(msg)
 	return nil
 }
 
+// staleResend reports whether a message being resent in answer to a
+// ResendRequest is an order instruction too old to act on. Such messages are
+// gap-filled rather than resent, and the cached order is updated as if Prime
+// had rejected them.
+func (a *FixApp) staleResend(msg *quickfix.Message) bool {
+	msgType, _ := msg.Header.GetString(constants.TagMsgType)
+	switch msgType {
+	case constants.MsgTypeNew, constants.MsgTypeCancel, constants.MsgTypeReplace:
+	default:
+		return false
+	}
+	origSendingTime, _ := msg.Header.GetString(constants.TagOrigSendingTime)
+	sent, ok := parseFixTime(origSendingTime)
+	if !ok || time.Since(sent) <= a.config.ResendMaxAge {
+		return false
+	}
+
+	ref := outboundRef{
+		MsgType:     msgType,
+		ClOrdId:     utils.GetString(msg, constants.TagClOrdId),
+		OrigClOrdId: utils.GetString(msg, constants.TagOrigClOrdId),
+	}
+	reason := fmt.Sprintf("not resent after reconnect: sent %s ago", time.Since(sent).Round(time.Second))
+	a.rejectOutbound(ref, reason)
+	a.notify(fmt.Sprintf("✗ %s %s %s", describeMsgType(msgType), ref.id(), reason))
+	return true
+}
+
+func isPossDup(msg *quickfix.Message) bool {
+	possDup, _ := msg.Header.GetString(constants.TagPossDupFlag)
+	return possDup == "Y"
+}
+
 func (a *FixApp) OnLogon(sid quickfix.SessionID) {
 	a.SessionId = sid
 	log.Println("✓ FIX logon", sid)
-	if err := a.loadOrders(); err != nil {
+	a.loggedOn.Store(true)
+	a.events.Publish(events.TypeSession, "", events.Session{Status: "logon", SessionId: sid.String()})
+	a.mu.Lock()
+	a.outbound = make(map[int]outboundRef)
+	err := a.loadOrders()
+	fillErr := a.loadFills()
+	rfqErr := a.loadRfqs()
+	a.rebuildPositionsLocked()
+	a.mu.Unlock()
+	if err != nil {
 		log.Println("order cache load err:", err)
 	}
-	fmt.Println("Commands: new, status, cancel, list, rfq, version, exit")
+	if fillErr != nil {
+		log.Println("fill ledger load err:", fillErr)
+	}
+	if rfqErr != nil {
+		log.Println("rfq load err:", rfqErr)
+	}
+	fmt.Fprintln(a.out, "Commands: new, status, cancel, replace, list, fills, positions, risk, instruments, rfq, rfqs, quotes, accept, pass, version, exit")
 }
 
 func (a *FixApp) ToAdmin(msg *quickfix.Message, _ quickfix.SessionID) {
 	if t, _ := msg.Header.GetString(constants.TagMsgType); t == constants.MsgTypeLogon {
 		ts := time.Now().UTC().Format(constants.FixTimeFormat)
+		// The signature covers the Logon's MsgSeqNum, which is only 1 when the
+		// session is being reset.
+		seqNum := "1"
+		if reset, _ := msg.Body.GetString(constants.TagResetSeqNumFlag); reset != "Y" {
+			if v, err := msg.Header.GetString(constants.TagMsgSeqNum); err == nil {
+				seqNum = v
+			}
+		}
+		if a.credentials == nil {
+			log.Println("logon error: no credentials configured")
+			return
+		}
+		creds, err := a.credentials.Fetch()
+		if err != nil {
+			log.Println("credential error:", err)
+			return
+		}
 		builder.BuildLogon(
 			&msg.Body,
 			ts,
-			a.config.AccessKey,
-			a.config.SigningKey,
-			a.config.Passphrase,
+			seqNum,
+			creds.AccessKey,
+			creds.SigningKey,
+			creds.Passphrase,
 			a.config.TargetCompId,
 			a.config.PortfolioId,
 		)
+		creds.Zero()
 	}
 }
 
 func (a *FixApp) FromApp(msg *quickfix.Message, _ quickfix.SessionID) quickfix.MessageRejectError {
 	msgType, _ := msg.Header.GetString(constants.TagMsgType)
 	switch msgType {
-	case "8":
+	case constants.MsgTypeExecReport:
 		a.handleExecReport(msg)
+	case constants.MsgTypeCancelReject:
+		a.handleCancelReject(msg)
+	case constants.MsgTypeBusinessReject:
+		a.handleBusinessReject(msg)
 	case constants.MsgTypeQuote:
 		a.handleQuote(msg)
 	case constants.MsgTypeQuoteAck:
@@ -105,157 +236,360 @@ func (a *FixApp) FromApp(msg *quickfix.Message, _ quickfix.SessionID) quickfix.M
 }
 
 func (a *FixApp) handleExecReport(msg *quickfix.Message) {
-	info := model.OrderInfo{
-		ClOrdId:    utils.GetString(msg, constants.TagClOrdId),
-		OrderId:    utils.GetString(msg, constants.TagOrderId),
-		Side:       utils.GetString(msg, constants.TagSide),
-		Symbol:     utils.GetString(msg, constants.TagSymbol),
-		Quantity:   utils.GetString(msg, constants.TagOrderQty),
-		LimitPrice: utils.GetString(msg, constants.TagPx),
-	}
-	if info.Quantity == "" {
-		info.Quantity = utils.GetString(msg, constants.TagCashOrderQty)
-	}
-	if info.ClOrdId == "" {
+	report := model.OrderInfo{
+		ClOrdId:      utils.GetString(msg, constants.TagClOrdId),
+		OrderId:      utils.GetString(msg, constants.TagOrderId),
+		Side:         utils.GetString(msg, constants.TagSide),
+		Symbol:       utils.GetString(msg, constants.TagSymbol),
+		Quantity:     utils.GetString(msg, constants.TagOrderQty),
+		LimitPrice:   utils.GetString(msg, constants.TagPx),
+		StopPx:       utils.GetString(msg, constants.TagStopPx),
+		ExecType:     utils.GetString(msg, constants.TagExecType),
+		CumQty:       utils.GetString(msg, constants.TagCumQty),
+		LeavesQty:    utils.GetString(msg, constants.TagLeavesQty),
+		AvgPx:        utils.GetString(msg, constants.TagAvgPx),
+		FilledAmount: utils.GetString(msg, constants.TagFilledAmount),
+		NetAvgPrice:  utils.GetString(msg, constants.TagNetAvgPrice),
+		Text:         utils.GetString(msg, constants.TagText),
+	}
+	if report.Quantity == "" {
+		report.Quantity = utils.GetString(msg, constants.TagCashOrderQty)
+	}
+	if report.ClOrdId == "" {
 		return
 	}
+	status := model.OrderStatusFromFix(utils.GetString(msg, constants.TagOrdStatus), report.ExecType)
+	fill, isFill := fillFromExecReport(msg)
+	origClOrdId := utils.GetString(msg, constants.TagOrigClOrdId)
 
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
-	existing, exists := a.orders[info.ClOrdId]
-	if !exists || (existing.OrderId == "" && info.OrderId != "") {
-		if info.OrderId == "" {
-			info.OrderId = existing.OrderId
+	// Cancel acknowledgements carry the cancel's own ClOrdID; they belong to
+	// the order named in OrigClOrdID. A replacement is the order its report
+	// names, even when this client did not track it.
+	key := report.ClOrdId
+	replaced := report.ExecType == constants.ExecTypeReplaced
+	if _, ok := a.orders[key]; !ok && origClOrdId != "" && !replaced {
+		if _, ok := a.orders[origClOrdId]; ok {
+			key = origClOrdId
 		}
-		a.orders[info.ClOrdId] = info
-		_ = a.saveOrders()
-		log.Printf("⇡ cached/updated %s (OrderId %s)", info.ClOrdId, info.OrderId)
 	}
-	if _, exists2 := a.orders[info.ClOrdId]; !exists2 {
-		if a.orders == nil {
-			a.orders = make(map[string]model.OrderInfo)
+
+	info, exists := a.orders[key]
+	if !exists {
+		info = model.OrderInfo{ClOrdId: key, OrigClOrdId: origClOrdId}
+		if orig, ok := a.orders[origClOrdId]; ok && replaced {
+			// The replacement keeps the original's terms unless the report
+			// says otherwise.
+			info = orig
+			info.ClOrdId = key
+			info.OrigClOrdId = origClOrdId
+			info.Status = ""
+			info.PrevStatus = ""
+			info.ReplacedBy = ""
+			info.IllegalTransition = ""
+			info.Outcome = ""
 		}
-		a.orders[info.ClOrdId] = info
-		_ = a.saveOrders()
-		log.Printf("⇡ cached %s (OrderId %s)", info.ClOrdId, info.OrderId)
 	}
-}
+	prevStatus := info.Status
 
-func (a *FixApp) handleQuote(msg *quickfix.Message) {
-	quote := model.QuoteInfo{
-		QuoteId:        utils.GetString(msg, constants.TagQuoteId),
-		QuoteReqId:     utils.GetString(msg, constants.TagQuoteReqId),
-		Account:        utils.GetString(msg, constants.TagAccount),
-		Symbol:         utils.GetString(msg, constants.TagSymbol),
-		BidPx:          utils.GetString(msg, constants.TagBidPx),
-		OfferPx:        utils.GetString(msg, constants.TagOfferPx),
-		BidSize:        utils.GetString(msg, constants.TagBidSize),
-		OfferSize:      utils.GetString(msg, constants.TagOfferSize),
-		ValidUntilTime: utils.GetString(msg, constants.TagValidUntilTime),
+	if replaced && key != origClOrdId {
+		// The replacement takes over the original's working state; REPLACED
+		// describes the order it superseded.
+		if status == "" || status == model.OrderStatusReplaced || status == model.OrderStatusPendingReplace {
+			cumQty := report.CumQty
+			if cumQty == "" {
+				cumQty = info.CumQty
+			}
+			status = model.OrderStatusNew
+			if _, err := amount.Positive(cumQty); err == nil {
+				status = model.OrderStatusPartiallyFilled
+			}
+		}
 	}
 
-	if quote.QuoteId == "" {
+	if status != "" && isPossDup(msg) && !model.CanTransition(info.Status, status) {
+		// A resent report older than what we already know. Its fill is still
+		// recorded if it was missed.
+		log.Printf("⇣ %s: ignoring resent %s report (order is %s)", key, status, info.Status)
+		if isFill && a.recordFillLocked(fill) {
+			a.applyFillLocked(fill)
+		}
 		return
 	}
 
-	log.Printf("✓ received quote %s for request %s", quote.QuoteId, quote.QuoteReqId)
+	mergeExecReport(&info, report)
+	if replaced && key != origClOrdId {
+		a.markReplaced(origClOrdId, key)
+	}
+	if status != "" {
+		if err := info.Transition(status); err != nil {
+			info.IllegalTransition = err.Error()
+			log.Printf("⚠ %s: %v (ExecType %s)", key, err, report.ExecType)
+		}
+	}
+	if info.Status != model.OrderStatusPendingCancel && info.Status != model.OrderStatusPendingReplace {
+		info.PrevStatus = ""
+	}
+	info.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	a.linkRfqOrderLocked(&info)
+	hadOutcome := info.Outcome != ""
+	info.Outcome = orderOutcome(info)
+
+	a.putOrderLocked(info)
 
-	if quote.BidPx != "" {
-		fmt.Printf("Quote: Bid %s @ %s (valid until %s)\n", quote.BidSize, quote.BidPx, quote.ValidUntilTime)
+	if isFill && a.recordFillLocked(fill) {
+		a.applyFillLocked(fill)
 	}
-	if quote.OfferPx != "" {
-		fmt.Printf("Quote: Offer %s @ %s (valid until %s)\n", quote.OfferSize, quote.OfferPx, quote.ValidUntilTime)
+
+	if info.Status != prevStatus {
+		log.Printf("⇡ %s %s → %s (filled %s @ %s)", key, orDash(prevStatus), info.Status, orDash(info.CumQty), orDash(info.AvgPx))
 	}
+	if !hadOutcome && info.Outcome != "" {
+		a.notify(fmt.Sprintf("✗ %s: %s", key, describeOutcome(info)))
+	}
+}
 
-	// Auto-accept the quote
-	a.autoAcceptQuote(quote)
+// orderOutcome classifies why an order ended without trading when the status
+// alone does not say, keeping any outcome already recorded.
+func orderOutcome(info model.OrderInfo) string {
+	if info.Outcome != "" {
+		return info.Outcome
+	}
+	if !builder.HasExecInst(info.ExecInst, constants.ExecInstPostOnly) {
+		return ""
+	}
+	if info.Status != model.OrderStatusRejected && info.Status != model.OrderStatusCanceled {
+		return ""
+	}
+	if _, err := amount.Positive(info.CumQty); err == nil {
+		return ""
+	}
+	text := strings.ToLower(info.Text)
+	if strings.Contains(text, "post") || strings.Contains(text, "cross") || strings.Contains(text, "maker") || strings.Contains(text, "take liquidity") {
+		return model.OutcomePostOnlyWouldCross
+	}
+	return ""
 }
 
-func (a *FixApp) autoAcceptQuote(quote model.QuoteInfo) {
-	var price, qty, side string
-	if quote.BidPx != "" {
-		price = quote.BidPx
-		qty = quote.BidSize
-		side = "SELL"
-	} else if quote.OfferPx != "" {
-		price = quote.OfferPx
-		qty = quote.OfferSize
-		side = "BUY"
-	} else {
-		log.Printf("✗ cannot auto-accept quote %s: no valid bid or offer price", quote.QuoteId)
-		return
+func describeOutcome(info model.OrderInfo) string {
+	switch info.Outcome {
+	case model.OutcomePostOnlyWouldCross:
+		return "post-only order not placed: it would have crossed the book"
 	}
+	return info.Outcome
+}
 
-	acceptMsg := builder.BuildAcceptQuote(quote.QuoteId, quote.Symbol, side, qty, price, a.config.PortfolioId, a.config)
-	err := quickfix.SendToTarget(acceptMsg, a.SessionId)
-	if err != nil {
+// markReplaced closes out the original order of a confirmed cancel/replace.
+// The caller must hold a.mu.
+func (a *FixApp) markReplaced(origClOrdId, clOrdId string) {
+	orig, ok := a.orders[origClOrdId]
+	if !ok {
 		return
 	}
-	log.Printf("✓ auto-accepting quote %s: %s %s %s @ %s", quote.QuoteId, side, qty, quote.Symbol, price)
+	if err := orig.Transition(model.OrderStatusReplaced); err != nil {
+		orig.IllegalTransition = err.Error()
+		log.Printf("⚠ %s: %v", origClOrdId, err)
+	}
+	orig.ReplacedBy = clOrdId
+	orig.PrevStatus = ""
+	orig.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	a.putOrderLocked(orig)
+	log.Printf("⇡ %s replaced by %s", origClOrdId, clOrdId)
+}
+
+// mergeExecReport copies every field the report carries onto the cached order,
+// keeping cached values for fields the report omits.
+func mergeExecReport(info *model.OrderInfo, report model.OrderInfo) {
+	set := func(dst *string, v string) {
+		if v != "" {
+			*dst = v
+		}
+	}
+	set(&info.OrderId, report.OrderId)
+	set(&info.Side, report.Side)
+	set(&info.Symbol, report.Symbol)
+	set(&info.Quantity, report.Quantity)
+	set(&info.LimitPrice, report.LimitPrice)
+	set(&info.StopPx, report.StopPx)
+	set(&info.ExecType, report.ExecType)
+	set(&info.CumQty, report.CumQty)
+	set(&info.LeavesQty, report.LeavesQty)
+	set(&info.AvgPx, report.AvgPx)
+	set(&info.FilledAmount, report.FilledAmount)
+	set(&info.NetAvgPrice, report.NetAvgPrice)
+	set(&info.Text, report.Text)
+}
+
+// trackOrder caches an order the client has just sent so that its first
+// ExecutionReport has a PENDING_NEW state to move from. A report that
+// overtook the send has already cached the order; what it reported is kept
+// and the fields only the request carries are added.
+func (a *FixApp) trackOrder(info model.OrderInfo) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if cached, ok := a.orders[info.ClOrdId]; ok {
+		mergeExecReport(&info, cached)
+		info.Status = cached.Status
+		info.IllegalTransition = cached.IllegalTransition
+		info.Outcome = cached.Outcome
+		info.Outcome = orderOutcome(info)
+	}
+	if info.Status == "" {
+		info.Status = model.OrderStatusPendingNew
+	}
+	info.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	a.putOrderLocked(info)
+}
+
+// claimPending moves a working order to PENDING_CANCEL or PENDING_REPLACE
+// before the request is sent, remembering its current state in case the
+// request is rejected, so that a second cancel or replace is refused rather
+// than sent as well. It returns the order as it was.
+func (a *FixApp) claimPending(clOrdId, pending, action string) (model.OrderInfo, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	info, ok := a.orders[clOrdId]
+	if !ok {
+		return info, notFound("unknown ClOrdId %s (not in cache)", clOrdId)
+	}
+	if info.Status == model.OrderStatusPendingReplace || info.Status == model.OrderStatusPendingCancel {
+		return info, conflict("order %s is %s; wait for it to settle", clOrdId, info.Status)
+	}
+	claimed := info
+	if err := claimed.Transition(pending); err != nil {
+		return info, conflict("order %s is %s and cannot be %s", clOrdId, info.Status, action)
+	}
+	claimed.PrevStatus = info.Status
+	claimed.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	a.putOrderLocked(claimed)
+	return info, nil
 }
 
-func (a *FixApp) handleQuoteAck(msg *quickfix.Message) {
-	quoteReqId := utils.GetString(msg, constants.TagQuoteReqId)
-	quoteAckStatus := utils.GetString(msg, constants.TagQuoteAckStatus)
-	rejectReason := utils.GetString(msg, constants.TagQuoteRejectReason)
-	text := utils.GetString(msg, constants.TagText)
+// releasePending returns an order claimed by claimPending to its saved state
+// when the request could not be sent.
+func (a *FixApp) releasePending(clOrdId string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.restorePendingLocked(clOrdId, "", time.Now().UTC().Format(time.RFC3339), nil)
+}
 
-	if quoteAckStatus == constants.QuoteAckStatusRejected {
-		log.Printf("✗ quote request %s rejected: reason=%s, text=%s", quoteReqId, rejectReason, text)
-	} else {
-		log.Printf("? quote acknowledgment for %s: status=%s", quoteReqId, quoteAckStatus)
+// trackReplace caches the amended order of an outbound cancel/replace under
+// its new ClOrdID, keeping anything a report that overtook the send recorded.
+func (a *FixApp) trackReplace(amended model.OrderInfo) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	amended.Status = model.OrderStatusPendingReplace
+	if cached, ok := a.orders[amended.ClOrdId]; ok {
+		mergeExecReport(&amended, cached)
+		amended.Status = cached.Status
+		amended.IllegalTransition = cached.IllegalTransition
 	}
+	amended.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	a.putOrderLocked(amended)
 }
 
-// Commands: new, status, cancel, list, exit.
+// Commands: new, status, cancel, replace, list, fills, positions, risk, instruments, rfq, rfqs, quotes, accept, pass, version, exit.
 func Repl(app *FixApp) {
-	reader := bufio.NewReader(os.Stdin)
+	reader := newLineReader(app.out, app.complete)
+	defer reader.Close()
+	replRunning.Store(true)
+	defer replRunning.Store(false)
 	for {
-		fmt.Print("FIX> ")
-		line, _ := reader.ReadString('\n')
+		line, err := reader.ReadLine("FIX> ")
+		if err == io.EOF {
+			return
+		}
 		parts := strings.Fields(strings.TrimSpace(line))
 		if len(parts) == 0 {
 			continue
 		}
-		cmd := strings.ToLower(parts[0])
-		switch cmd {
-		case "new":
-			app.handleNew(parts)
-		case "status":
-			app.handleStatus(parts)
-		case "cancel":
-			app.handleCancel(parts)
-		case "list":
-			app.handleList()
-		case "rfq":
-			app.handleRfq(parts)
-		case "version":
-			fmt.Println(utils.FullVersion())
-		case "exit":
+		if _, _, exit := app.dispatch(parts); exit {
 			return
-		default:
-			fmt.Println("unknown command")
 		}
 	}
 }
 
-func (a *FixApp) saveOrders() error {
-	data, err := json.MarshalIndent(a.orders, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal orders: %w", err)
+// dispatch runs one command. It returns the order or RFQ the command sent, if
+// any, whether the command succeeded, and whether it asked to exit.
+func (a *FixApp) dispatch(parts []string) (sent pendingAck, ok bool, exit bool) {
+	ok = true
+	switch strings.ToLower(parts[0]) {
+	case "new":
+		sent, ok = a.handleNew(parts)
+	case "status":
+		ok = a.handleStatus(parts)
+	case "cancel":
+		sent, ok = a.handleCancel(parts)
+	case "replace":
+		sent, ok = a.handleReplace(parts)
+	case "list":
+		a.handleList()
+	case "fills":
+		a.handleFills(parts)
+	case "positions":
+		a.handlePositions(parts)
+	case "risk":
+		a.handleRisk(parts)
+	case "instruments":
+		a.handleInstruments(parts)
+	case "rfq":
+		sent, ok = a.handleRfq(parts)
+	case "rfqs":
+		a.handleRfqs()
+	case "quotes":
+		a.handleQuotes()
+	case "accept":
+		sent, ok = a.handleAccept(parts)
+	case "pass":
+		ok = a.handlePass(parts)
+	case "version":
+		fmt.Fprintln(a.out, utils.FullVersion())
+	case "exit":
+		exit = true
+	default:
+		fmt.Fprintln(a.out, "unknown command")
+		ok = false
+	}
+	return sent, ok, exit
+}
+
+// putOrderLocked updates the cached order and persists it. The caller must
+// hold a.mu.
+func (a *FixApp) putOrderLocked(info model.OrderInfo) {
+	a.orders[info.ClOrdId] = info
+	if err := a.store.SaveOrder(info); err != nil {
+		log.Println("order cache save err:", err)
+	}
+	a.events.Publish(events.TypeOrder, info.Symbol, info)
+	if info.QuoteReqId != "" {
+		a.syncRfqLocked(info)
 	}
-	return os.WriteFile(orderFile, data, 0o644)
 }
 
 func (a *FixApp) loadOrders() error {
-	data, err := os.ReadFile(orderFile)
+	orders, err := a.store.LoadOrders()
 	if err != nil {
-		if os.IsNotExist(err) {
-			a.orders = make(map[string]model.OrderInfo)
-			return nil
-		}
 		return err
 	}
-	return json.Unmarshal(data, &a.orders)
+	a.orders = orders
+	return nil
+}
+
+func (a *FixApp) loadFills() error {
+	fills, err := a.store.LoadFills()
+	if err != nil {
+		return err
+	}
+	a.fills = a.fills[:0]
+	a.fillIds = make(map[string]struct{}, len(fills))
+	for _, f := range fills {
+		if _, seen := a.fillIds[f.ExecId]; seen {
+			continue
+		}
+		a.fills = append(a.fills, f)
+		a.fillIds[f.ExecId] = struct{}{}
+	}
+	return nil
 }
@@ -0,0 +1,217 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fixclient
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"prime-fix-go/builder"
+	"prime-fix-go/constants"
+	"prime-fix-go/model"
+	"prime-fix-go/store"
+
+	"github.com/quickfixgo/quickfix"
+)
+
+// newTestApp returns a FixApp backed by a memory store. Its session is
+// registered but never started, so sent messages pass through ToApp and are
+// queued without a counterparty.
+func newTestApp(t *testing.T) *FixApp {
+	t.Helper()
+	config := &constants.Config{
+		SenderCompId: "TEST-" + strings.ReplaceAll(t.Name(), "/", "-"),
+		TargetCompId: "COIN",
+		PortfolioId:  "portfolio",
+		ResendMaxAge: constants.DefaultResendMaxAge,
+	}
+	app := NewFixApp(config, store.NewMemoryStore())
+	app.SetOutput(io.Discard)
+
+	session := quickfix.NewSessionSettings()
+	session.Set("BeginString", quickfix.BeginStringFIX42)
+	session.Set("SenderCompID", config.SenderCompId)
+	session.Set("TargetCompID", config.TargetCompId)
+	session.Set("HeartBtInt", "30")
+	session.Set("SocketConnectHost", "127.0.0.1")
+	session.Set("SocketConnectPort", "1")
+	settings := quickfix.NewSettings()
+	if _, err := settings.AddSession(session); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := quickfix.NewInitiator(app, quickfix.NewMemoryStoreFactory(), settings, quickfix.NewNullLogFactory()); err != nil {
+		t.Fatalf("NewInitiator returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = quickfix.UnregisterSession(app.SessionId) })
+	return app
+}
+
+// inbound builds a message as Prime would send it.
+func inbound(msgType string, fields map[quickfix.Tag]string) *quickfix.Message {
+	msg := quickfix.NewMessage()
+	msg.Header.SetString(constants.TagMsgType, msgType)
+	for tag, value := range fields {
+		msg.Body.SetString(tag, value)
+	}
+	return msg
+}
+
+// deliver hands msg to the app the way the session would.
+func deliver(app *FixApp, msg *quickfix.Message) {
+	if msgType, _ := msg.Header.GetString(constants.TagMsgType); msgType == constants.MsgTypeReject {
+		app.FromAdmin(msg, app.SessionId)
+		return
+	}
+	app.FromApp(msg, app.SessionId)
+}
+
+// execReport builds an ExecutionReport for clOrdId.
+func execReport(clOrdId, execType, ordStatus string, fields map[quickfix.Tag]string) *quickfix.Message {
+	body := map[quickfix.Tag]string{
+		constants.TagClOrdId:   clOrdId,
+		constants.TagOrderId:   "order-" + clOrdId,
+		constants.TagExecType:  execType,
+		constants.TagOrdStatus: ordStatus,
+		constants.TagSymbol:    "BTC-USD",
+		constants.TagSide:      constants.SideBuyFix,
+	}
+	for tag, value := range fields {
+		body[tag] = value
+	}
+	return inbound(constants.MsgTypeExecReport, body)
+}
+
+// placeOrder places a limit buy for 1 BTC-USD and returns its ClOrdID.
+func placeOrder(t *testing.T, app *FixApp) string {
+	t.Helper()
+	info, err := app.PlaceOrder(builder.NewOrderRequest{
+		Symbol:  "BTC-USD",
+		OrdType: "LIMIT",
+		Side:    "BUY",
+		QtyType: "BASE",
+		Qty:     "1",
+		Price:   "50000",
+	})
+	if err != nil {
+		t.Fatalf("PlaceOrder returned error: %v", err)
+	}
+	return info.ClOrdId
+}
+
+// placeWorking places an order with placeOrder and acknowledges it.
+func placeWorking(t *testing.T, app *FixApp) string {
+	t.Helper()
+	clOrdId := placeOrder(t, app)
+	deliver(app, execReport(clOrdId, constants.ExecTypeNew, constants.OrdStatusNew, map[quickfix.Tag]string{
+		constants.TagCumQty:    "0",
+		constants.TagLeavesQty: "1",
+	}))
+	if info, _ := app.Order(clOrdId); info.Status != model.OrderStatusNew {
+		t.Fatalf("Expected placed order to be %s, got %s", model.OrderStatusNew, info.Status)
+	}
+	return clOrdId
+}
+
+func TestTrackOrderKeepsReportThatOvertookSend(t *testing.T) {
+	tests := []struct {
+		name        string
+		report      *quickfix.Message
+		wantStatus  string
+		wantOutcome string
+	}{
+		{
+			name:       "acknowledged",
+			report:     execReport("c-1", constants.ExecTypeNew, constants.OrdStatusNew, map[quickfix.Tag]string{constants.TagLeavesQty: "1"}),
+			wantStatus: model.OrderStatusNew,
+		},
+		{
+			name: "post-only rejected",
+			report: execReport("c-1", constants.ExecTypeRejected, constants.OrdStatusRejected, map[quickfix.Tag]string{
+				constants.TagText: "post only order would cross",
+			}),
+			wantStatus:  model.OrderStatusRejected,
+			wantOutcome: model.OutcomePostOnlyWouldCross,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApp(t)
+			deliver(app, tt.report)
+			app.trackOrder(model.OrderInfo{
+				ClOrdId:     "c-1",
+				Side:        constants.SideBuyFix,
+				Symbol:      "BTC-USD",
+				Quantity:    "1",
+				LimitPrice:  "50000",
+				OrdType:     constants.OrdTypeLimit,
+				TimeInForce: "GTC",
+				ExecInst:    constants.ExecInstPostOnly,
+			})
+
+			info, _ := app.Order("c-1")
+			if info.Status != tt.wantStatus || info.Outcome != tt.wantOutcome {
+				t.Errorf("Expected %s with outcome %q, got %s with %q", tt.wantStatus, tt.wantOutcome, info.Status, info.Outcome)
+			}
+			if info.OrderId != "order-c-1" || info.OrdType != constants.OrdTypeLimit || info.TimeInForce != "GTC" {
+				t.Errorf("Expected reported and requested fields merged, got %+v", info)
+			}
+		})
+	}
+}
+
+func TestUntrackedReplacementIsCached(t *testing.T) {
+	app := newTestApp(t)
+	clOrdId := placeWorking(t, app)
+
+	deliver(app, execReport("r-1", constants.ExecTypeReplaced, constants.OrdStatusNew, map[quickfix.Tag]string{
+		constants.TagOrigClOrdId: clOrdId,
+		constants.TagOrderQty:    "1",
+		constants.TagPx:          "49000",
+		constants.TagLeavesQty:   "1",
+	}))
+
+	orig, _ := app.Order(clOrdId)
+	if orig.Status != model.OrderStatusReplaced || orig.ReplacedBy != "r-1" || orig.LimitPrice != "50000" {
+		t.Errorf("Expected original %s by r-1 at 50000, got %s by %q at %s", model.OrderStatusReplaced, orig.Status, orig.ReplacedBy, orig.LimitPrice)
+	}
+	replacement, ok := app.Order("r-1")
+	if !ok || replacement.Status != model.OrderStatusNew || replacement.OrigClOrdId != clOrdId {
+		t.Fatalf("Expected replacement %s replacing %s, got %+v", model.OrderStatusNew, clOrdId, replacement)
+	}
+	if replacement.LimitPrice != "49000" || replacement.OrdType != constants.OrdTypeLimit {
+		t.Errorf("Expected a LIMIT replacement at 49000, got %s at %s", replacement.OrdType, replacement.LimitPrice)
+	}
+}
+
+func TestNotificationsGoToOutput(t *testing.T) {
+	app := newTestApp(t)
+	var out bytes.Buffer
+	app.SetOutput(&out)
+	clOrdId := placeOrder(t, app)
+
+	deliver(app, inbound(constants.MsgTypeBusinessReject, map[quickfix.Tag]string{
+		constants.TagRefMsgType:           constants.MsgTypeNew,
+		constants.TagBusinessRejectRefId:  clOrdId,
+		constants.TagBusinessRejectReason: "2",
+	}))
+
+	if want := "order " + clOrdId + " rejected: unknown security"; !strings.Contains(out.String(), want) {
+		t.Errorf("Expected output to contain %q, got %q", want, out.String())
+	}
+}
@@ -0,0 +1,179 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fixclient
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"prime-fix-go/amount"
+	"prime-fix-go/constants"
+	"prime-fix-go/instruments"
+	"prime-fix-go/positions"
+)
+
+// SetInstruments installs the catalog new orders and RFQs are checked
+// against.
+func (a *FixApp) SetInstruments(catalog *instruments.Catalog) {
+	a.instruments = catalog
+}
+
+// checkInstrument validates an order against the instrument catalog. It
+// returns the canonical symbol and the price and stop price to send, which
+// differ from the input only when RoundToTick is set; callers compare them to
+// report the rounding. Without a catalog every order passes unchanged.
+func (a *FixApp) checkInstrument(symbol, side, qtyType, qty, price, stopPx string) (string, string, string, error) {
+	if a.instruments == nil {
+		return symbol, price, stopPx, nil
+	}
+	if side == constants.SideSellFix {
+		side = constants.SideSell
+	}
+	order := instruments.Order{Symbol: symbol, Side: side, QtyType: qtyType}
+	order.Qty, _ = amount.Parse(qty)
+	if price != "" {
+		order.Price, _ = amount.Parse(price)
+	}
+	if stopPx != "" {
+		order.StopPx, _ = amount.Parse(stopPx)
+	}
+	checked, err := a.instruments.Check(order, a.config.RoundToTick)
+	if err != nil {
+		return symbol, price, stopPx, err
+	}
+	if !checked.Price.Equal(order.Price) {
+		price = amount.Format(checked.Price)
+	}
+	if !checked.StopPx.Equal(order.StopPx) {
+		stopPx = amount.Format(checked.StopPx)
+	}
+	return checked.Symbol, price, stopPx, nil
+}
+
+// printRounded reports a price the instrument check moved to the tick.
+func (a *FixApp) printRounded(name, requested, sent string) {
+	if requested == "" || sent == "" {
+		return
+	}
+	req, err := amount.Parse(requested)
+	if err != nil {
+		return
+	}
+	if got, err := amount.Parse(sent); err == nil && !got.Equal(req) {
+		fmt.Fprintf(a.out, "rounded %s %s to %s\n", name, requested, sent)
+	}
+}
+
+func (a *FixApp) handleInstruments(parts []string) {
+	if a.instruments == nil {
+		fmt.Fprintln(a.out, "(no instrument catalog configured; set InstrumentsFile in fix.cfg)")
+		return
+	}
+	prefix := ""
+	if len(parts) > 1 {
+		if parts[1] == "reload" {
+			if err := a.instruments.Reload(); err != nil {
+				fmt.Fprintf(a.out, "error reloading instruments: %v\n", err)
+				return
+			}
+			fmt.Fprintf(a.out, "reloaded %d instruments from %s\n", a.instruments.Len(), a.instruments.Path())
+			return
+		}
+		prefix = parts[1]
+	}
+
+	symbols := a.instruments.Symbols(prefix)
+	if len(symbols) == 0 {
+		fmt.Fprintln(a.out, "(no instruments)")
+		return
+	}
+	fmt.Fprintf(a.out, "%-14s %14s %14s %14s %14s %14s %14s %14s\n",
+		"SYMBOL", "TICK", "BASE INCR", "BASE MIN", "BASE MAX", "QUOTE INCR", "QUOTE MIN", "QUOTE MAX")
+	for _, symbol := range symbols {
+		inst, _ := a.instruments.Lookup(symbol)
+		fmt.Fprintf(a.out, "%-14s %14s %14s %14s %14s %14s %14s %14s\n", inst.Symbol,
+			orDash(amountText(inst.PriceIncrement)), orDash(amountText(inst.BaseIncrement)),
+			orDash(amountText(inst.BaseMinSize)), orDash(amountText(inst.BaseMaxSize)),
+			orDash(amountText(inst.QuoteIncrement)), orDash(amountText(inst.QuoteMinSize)),
+			orDash(amountText(inst.QuoteMaxSize)))
+	}
+}
+
+var (
+	sideWords    = []string{constants.SideBuy, constants.SideSell}
+	qtyTypeWords = []string{"BASE", "QUOTE"}
+)
+
+// completions lists the words Tab can complete for each argument of a
+// command; symbolArg is the position that takes a symbol.
+var completions = map[string]struct {
+	symbolArg int
+	args      [][]string
+}{
+	"new": {symbolArg: 1, args: [][]string{
+		2: {constants.OrdTypeMarket, constants.OrdTypeLimit, constants.OrdTypeStopLimit, constants.OrdTypeVwap, constants.OrdTypeTwap},
+		3: sideWords,
+		4: qtyTypeWords,
+	}},
+	"rfq":         {symbolArg: 1, args: [][]string{2: sideWords, 3: qtyTypeWords}},
+	"positions":   {symbolArg: 1, args: [][]string{1: {positions.ModeFifo, positions.ModeAvgCost}}},
+	"instruments": {symbolArg: 1, args: [][]string{1: {"reload"}}},
+}
+
+var commands = []string{
+	"new", "status", "cancel", "replace", "list", "fills", "positions", "risk", "instruments",
+	"rfq", "rfqs", "quotes", "accept", "pass", "version", "exit",
+}
+
+// complete returns the candidates for the last word of a partly typed line:
+// command names first, then symbols from the catalog and the fixed keywords
+// of each command.
+func (a *FixApp) complete(line string) []string {
+	words := strings.Split(line, " ")
+	word := words[len(words)-1]
+	pos := len(words) - 1
+
+	var candidates []string
+	if pos == 0 {
+		candidates = commands
+	} else if c, ok := completions[strings.ToLower(words[0])]; ok {
+		if pos < len(c.args) {
+			candidates = c.args[pos]
+		}
+		if pos == c.symbolArg && a.instruments != nil {
+			candidates = append(a.instruments.Symbols(word), candidates...)
+		}
+	}
+
+	var matches []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(strings.ToUpper(candidate), strings.ToUpper(word)) {
+			matches = append(matches, candidate)
+		}
+	}
+	return matches
+}
+
+// amountText formats a reference amount, leaving unset ones blank.
+func amountText(d decimal.Decimal) string {
+	if d.IsZero() {
+		return ""
+	}
+	return amount.Format(d)
+}
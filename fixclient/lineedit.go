@@ -0,0 +1,220 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fixclient
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+)
+
+// lineReader reads REPL input. On a terminal it switches the tty to
+// character-at-a-time mode with stty so Tab can complete the current word;
+// otherwise, or when stty is unavailable, it reads plain lines. Its echo and
+// completions are written to out.
+type lineReader struct {
+	in          *bufio.Reader
+	out         io.Writer
+	complete    func(line string) []string
+	saved       string
+	runes       chan typedRune
+	signals     chan os.Signal
+	interrupted bool
+}
+
+// typedRune is one read from the terminal.
+type typedRune struct {
+	c   rune
+	err error
+}
+
+func newLineReader(out io.Writer, complete func(line string) []string) *lineReader {
+	r := &lineReader{in: bufio.NewReader(os.Stdin), out: out, complete: complete}
+	if info, err := os.Stdin.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return r
+	}
+	saved, err := stty("-g")
+	if err != nil {
+		return r
+	}
+	if _, err := stty("-icanon", "-echo", "min", "1"); err != nil {
+		return r
+	}
+	r.saved = strings.TrimSpace(saved)
+
+	// An interrupt ends input like Ctrl-D, so the REPL returns and the
+	// client shuts down cleanly; reads happen on their own goroutine so that
+	// a pending one does not hold it up.
+	r.signals = make(chan os.Signal, 1)
+	signal.Notify(r.signals, os.Interrupt, syscall.SIGTERM)
+	r.runes = make(chan typedRune)
+	go func() {
+		for {
+			c, _, err := r.in.ReadRune()
+			r.runes <- typedRune{c, err}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return r
+}
+
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	return string(out), err
+}
+
+// Close restores the terminal settings and stops catching interrupts.
+func (r *lineReader) Close() {
+	if r.signals != nil {
+		signal.Stop(r.signals)
+	}
+	if r.saved != "" {
+		_, _ = stty(r.saved)
+		r.saved = ""
+	}
+}
+
+// next returns the next key typed on the terminal, or io.EOF once the client
+// has been interrupted, after giving the terminal back.
+func (r *lineReader) next() (rune, error) {
+	if r.interrupted {
+		return 0, io.EOF
+	}
+	select {
+	case typed := <-r.runes:
+		return typed.c, typed.err
+	case <-r.signals:
+		r.interrupted = true
+		r.Close()
+		fmt.Fprintln(r.out)
+		return 0, io.EOF
+	}
+}
+
+// ReadLine prints prompt and returns the next line without its newline. It
+// returns io.EOF at the end of input, or on Ctrl-D at an empty prompt.
+func (r *lineReader) ReadLine(prompt string) (string, error) {
+	fmt.Fprint(r.out, prompt)
+	if r.runes == nil {
+		line, err := r.in.ReadString('\n')
+		if err != nil && line == "" {
+			return "", err
+		}
+		return strings.TrimRight(line, "\r\n"), nil
+	}
+
+	var line []rune
+	for {
+		c, err := r.next()
+		if err != nil {
+			return string(line), err
+		}
+		switch c {
+		case '\r', '\n':
+			fmt.Fprintln(r.out)
+			return string(line), nil
+		case 0x04: // Ctrl-D
+			if len(line) == 0 {
+				fmt.Fprintln(r.out)
+				return "", io.EOF
+			}
+		case 0x7f, 0x08: // Backspace
+			if len(line) > 0 {
+				line = line[:len(line)-1]
+				fmt.Fprint(r.out, "\b \b")
+			}
+		case 0x15: // Ctrl-U
+			fmt.Fprint(r.out, strings.Repeat("\b \b", len(line)))
+			line = line[:0]
+		case 0x1b:
+			r.skipEscape()
+		case '\t':
+			line = r.completeLine(prompt, line)
+		default:
+			if c >= ' ' {
+				line = append(line, c)
+				fmt.Fprint(r.out, string(c))
+			}
+		}
+	}
+}
+
+// skipEscape discards the rest of an ANSI escape sequence such as an arrow
+// key, which the reader does not support.
+func (r *lineReader) skipEscape() {
+	c, err := r.next()
+	if err != nil || (c != '[' && c != 'O') {
+		return
+	}
+	for {
+		c, err = r.next()
+		if err != nil || (c >= 0x40 && c <= 0x7e) {
+			return
+		}
+	}
+}
+
+// completeLine completes the last word of line. A single match is inserted
+// in full; several matches are extended to their common prefix, or listed
+// when there is nothing to add.
+func (r *lineReader) completeLine(prompt string, line []rune) []rune {
+	if r.complete == nil {
+		return line
+	}
+	text := string(line)
+	word := text[strings.LastIndex(text, " ")+1:]
+	matches := r.complete(text)
+	if len(matches) == 0 {
+		return line
+	}
+	if len(matches) == 1 {
+		return r.replaceWord(line, word, matches[0]+" ")
+	}
+	prefix := commonPrefix(matches)
+	if len(prefix) > len(word) {
+		return r.replaceWord(line, word, prefix)
+	}
+	fmt.Fprintf(r.out, "\n%s\n%s%s", strings.Join(matches, "  "), prompt, text)
+	return line
+}
+
+// replaceWord swaps the trailing word of line for s, which may differ from
+// it in case.
+func (r *lineReader) replaceWord(line []rune, word, s string) []rune {
+	n := len([]rune(word))
+	fmt.Fprint(r.out, strings.Repeat("\b \b", n)+s)
+	return append(line[:len(line)-n], []rune(s)...)
+}
+
+func commonPrefix(words []string) string {
+	prefix := words[0]
+	for _, w := range words[1:] {
+		for !strings.HasPrefix(w, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
@@ -0,0 +1,46 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fixclient
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestReadLineEndsOnInterrupt(t *testing.T) {
+	var out bytes.Buffer
+	r := &lineReader{out: &out, runes: make(chan typedRune), signals: make(chan os.Signal, 1)}
+	go func() {
+		for _, c := range "ne" {
+			r.runes <- typedRune{c: c}
+		}
+		r.signals <- os.Interrupt
+	}()
+
+	line, err := r.ReadLine("FIX> ")
+	if err != io.EOF || line != "ne" {
+		t.Errorf("Expected %q and io.EOF, got %q and %v", "ne", line, err)
+	}
+	if _, err := r.ReadLine("FIX> "); err != io.EOF {
+		t.Errorf("Expected io.EOF after the interrupt, got %v", err)
+	}
+	if out.String() != "FIX> ne\nFIX> " {
+		t.Errorf("Expected the prompt and echo on the reader's output, got %q", out.String())
+	}
+}
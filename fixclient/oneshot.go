@@ -0,0 +1,113 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fixclient
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"prime-fix-go/events"
+	"prime-fix-go/model"
+)
+
+// OneShotCommands lists the commands RunOnce accepts.
+var OneShotCommands = []string{"new", "cancel", "status"}
+
+// RunOnce performs a single new, cancel or status command for a command-line
+// invocation. It waits up to timeout for the session to log on, sends the
+// request, waits up to timeout again for the ExecutionReport that answers it
+// and returns the order as then cached. Args are those of the REPL command.
+func (a *FixApp) RunOnce(args []string, timeout time.Duration) (model.OrderInfo, error) {
+	if len(args) == 0 {
+		return model.OrderInfo{}, fmt.Errorf("no command (%s)", strings.Join(OneShotCommands, ", "))
+	}
+	cmd := strings.ToLower(args[0])
+	switch cmd {
+	case "new":
+		req, err := parseNewOrder(args[1:])
+		if err != nil {
+			return model.OrderInfo{}, err
+		}
+		return a.runOnce(timeout, func() (pendingAck, error) {
+			info, err := a.PlaceOrder(req)
+			return pendingAck{sentOrder, info.ClOrdId}, err
+		})
+	case "cancel", "status":
+		if len(args) != 2 {
+			return model.OrderInfo{}, fmt.Errorf("usage: %s <ClOrdId>", cmd)
+		}
+	default:
+		return model.OrderInfo{}, fmt.Errorf("unknown command %q (%s)", args[0], strings.Join(OneShotCommands, ", "))
+	}
+
+	clOrdId := args[1]
+	if cmd == "status" {
+		return a.requestStatusOnce(clOrdId, timeout)
+	}
+	return a.runOnce(timeout, func() (pendingAck, error) {
+		_, err := a.CancelOrder(clOrdId)
+		return pendingAck{sentCancel, clOrdId}, err
+	})
+}
+
+// runOnce waits for logon, sends a request and waits for it to be
+// acknowledged.
+func (a *FixApp) runOnce(timeout time.Duration, send func() (pendingAck, error)) (model.OrderInfo, error) {
+	if err := a.waitFor(timeout, a.LoggedOn); err != nil {
+		return model.OrderInfo{}, fmt.Errorf("not logged on: %w", err)
+	}
+	sent, err := send()
+	if err != nil {
+		info, _ := a.Order(sent.id)
+		return info, err
+	}
+	_, err = a.awaitAck(sent, timeout)
+	info, _ := a.Order(sent.id)
+	return info, err
+}
+
+// requestStatusOnce sends an Order Status Request and waits for the
+// ExecutionReport that answers it, which need not change the order's status.
+func (a *FixApp) requestStatusOnce(clOrdId string, timeout time.Duration) (model.OrderInfo, error) {
+	if err := a.waitFor(timeout, a.LoggedOn); err != nil {
+		return model.OrderInfo{}, fmt.Errorf("not logged on: %w", err)
+	}
+	sub := a.events.Subscribe(events.Filter{Types: []string{events.TypeOrder}}, 0)
+	defer sub.Close()
+	if info, err := a.RequestStatus(clOrdId); err != nil {
+		return info, err
+	}
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	for {
+		select {
+		case e, ok := <-sub.Events:
+			if !ok {
+				info, _ := a.Order(clOrdId)
+				return info, nil
+			}
+			if info, isOrder := e.Data.(model.OrderInfo); isOrder && info.ClOrdId == clOrdId {
+				return info, nil
+			}
+		case <-deadline.C:
+			info, _ := a.Order(clOrdId)
+			return info, fmt.Errorf("no status report for %s: %w after %s", clOrdId, ErrTimeout, timeout)
+		}
+	}
+}
@@ -0,0 +1,344 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fixclient
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"prime-fix-go/amount"
+	"prime-fix-go/builder"
+	"prime-fix-go/constants"
+	"prime-fix-go/model"
+	"prime-fix-go/utils"
+
+	"github.com/quickfixgo/quickfix"
+)
+
+// Errors wrapped by the order operations, for callers that need to tell an
+// unknown order or quote from one whose state does not allow the request, from
+// a message the session did not accept, or from one Prime rejected or did not
+// answer in time.
+var (
+	ErrNotFound = errors.New("not found")
+	ErrConflict = errors.New("conflict")
+	ErrNotSent  = errors.New("not sent")
+	ErrRejected = errors.New("rejected")
+	ErrTimeout  = errors.New("timed out")
+)
+
+// kindError carries a message of its own while matching ErrNotFound or
+// ErrConflict through errors.Is.
+type kindError struct {
+	kind error
+	msg  string
+}
+
+func (e *kindError) Error() string { return e.msg }
+func (e *kindError) Unwrap() error { return e.kind }
+
+func notFound(format string, args ...any) error {
+	return &kindError{ErrNotFound, fmt.Sprintf(format, args...)}
+}
+
+func conflict(format string, args ...any) error {
+	return &kindError{ErrConflict, fmt.Sprintf(format, args...)}
+}
+
+// send hands msg to the session, marking a failure with ErrNotSent.
+func (a *FixApp) send(msg *quickfix.Message) error {
+	if err := quickfix.SendToTarget(msg, a.SessionId); err != nil {
+		return fmt.Errorf("%w: %v", ErrNotSent, err)
+	}
+	return nil
+}
+
+// Amendment lists the fields a cancel/replace may change; empty fields keep
+// the order's current value.
+type Amendment struct {
+	Price      string `json:"price,omitempty"`
+	StopPx     string `json:"stopPx,omitempty"`
+	Qty        string `json:"qty,omitempty"`
+	ExpireTime string `json:"expireTime,omitempty"`
+}
+
+// PlaceOrder validates req, runs the instrument and risk checks, sends the
+// New Order Single and returns the order as cached, ClOrdID included. An
+// empty portfolio defaults to the configured one.
+func (a *FixApp) PlaceOrder(req builder.NewOrderRequest) (model.OrderInfo, error) {
+	if req.Portfolio == "" {
+		req.Portfolio = a.config.PortfolioId
+	}
+	req.OrdType = strings.ToUpper(req.OrdType)
+	req.Side = strings.ToUpper(req.Side)
+	req.QtyType = strings.ToUpper(req.QtyType)
+	req.TimeInForce = strings.ToUpper(req.TimeInForce)
+	if err := req.Validate(); err != nil {
+		return model.OrderInfo{}, err
+	}
+	req = req.Normalize()
+
+	var err error
+	req.Symbol, req.Price, req.StopPx, err = a.checkInstrument(req.Symbol, req.Side, req.QtyType, req.Qty, req.Price, req.StopPx)
+	if err != nil {
+		return model.OrderInfo{}, err
+	}
+	if err := a.checkRisk(req.Symbol, req.QtyType, req.Qty, req.Price, false); err != nil {
+		return model.OrderInfo{}, err
+	}
+
+	msg, err := builder.BuildNewOrder(req, a.config)
+	if err != nil {
+		return model.OrderInfo{}, err
+	}
+	if err := a.send(msg); err != nil {
+		return model.OrderInfo{}, err
+	}
+
+	clOrdId := utils.GetString(msg, constants.TagClOrdId)
+	a.trackOrder(model.OrderInfo{
+		ClOrdId:           clOrdId,
+		Side:              utils.GetString(msg, constants.TagSide),
+		Symbol:            req.Symbol,
+		Quantity:          req.Qty,
+		LimitPrice:        req.Price,
+		StopPx:            req.StopPx,
+		OrdType:           req.OrdType,
+		QtyType:           req.QtyType,
+		TimeInForce:       req.TimeInForce,
+		StartTime:         req.StartTime,
+		ExpireTime:        req.ExpireTime,
+		ParticipationRate: req.ParticipationRate,
+		ExecInst:          req.ExecInst,
+	})
+	info, _ := a.Order(clOrdId)
+	return info, nil
+}
+
+// CancelOrder sends an Order Cancel Request for a cached order and returns it
+// in PENDING_CANCEL.
+func (a *FixApp) CancelOrder(clOrdId string) (model.OrderInfo, error) {
+	info, err := a.claimPending(clOrdId, model.OrderStatusPendingCancel, "canceled")
+	if err != nil {
+		return info, err
+	}
+	msg, err := builder.BuildCancelOrder(builder.CancelRequest{
+		Portfolio:   a.config.PortfolioId,
+		OrigClOrdId: info.ClOrdId,
+		OrderId:     info.OrderId,
+		Symbol:      info.Symbol,
+		Side:        info.Side,
+		Qty:         info.Quantity,
+	}, a.config)
+	if err == nil {
+		err = a.send(msg)
+	}
+	if err != nil {
+		a.releasePending(clOrdId)
+		return info, err
+	}
+	info, _ = a.Order(clOrdId)
+	return info, nil
+}
+
+// ReplaceOrder sends a cancel/replace for a working order and returns the
+// amended order, cached under its new ClOrdID.
+func (a *FixApp) ReplaceOrder(clOrdId string, change Amendment) (model.OrderInfo, error) {
+	orig, ok := a.Order(clOrdId)
+	if !ok {
+		return orig, notFound("unknown ClOrdId %s (not in cache)", clOrdId)
+	}
+	if change == (Amendment{}) {
+		return orig, fmt.Errorf("nothing to replace (price, stop, qty, expire)")
+	}
+
+	amended := orig
+	amounts := []struct {
+		name  string
+		value string
+		dst   *string
+	}{
+		{builder.FieldPrice, change.Price, &amended.LimitPrice},
+		{builder.FieldStopPx, change.StopPx, &amended.StopPx},
+		{builder.FieldQty, change.Qty, &amended.Quantity},
+	}
+	for _, f := range amounts {
+		if f.value == "" {
+			continue
+		}
+		wire, err := amount.Normalize(f.value)
+		if err != nil {
+			return orig, &builder.ValidationError{Fields: []*builder.FieldError{{Field: f.name, Value: f.value, Reason: err.Error()}}}
+		}
+		*f.dst = wire
+	}
+	if change.ExpireTime != "" {
+		amended.ExpireTime = change.ExpireTime
+	}
+	amended.OrigClOrdId = orig.ClOrdId
+	amended.PrevStatus = ""
+	amended.ReplacedBy = ""
+	amended.IllegalTransition = ""
+
+	var err error
+	_, amended.LimitPrice, amended.StopPx, err = a.checkInstrument(
+		amended.Symbol, amended.Side, amended.QtyType, amended.Quantity, amended.LimitPrice, amended.StopPx)
+	if err != nil {
+		return orig, err
+	}
+	if err := a.checkRisk(amended.Symbol, amended.QtyType, amended.Quantity, amended.LimitPrice, true); err != nil {
+		return orig, err
+	}
+
+	if orig, err = a.claimPending(clOrdId, model.OrderStatusPendingReplace, "replaced"); err != nil {
+		return orig, err
+	}
+	msg, err := builder.BuildCancelReplace(amended, a.config.PortfolioId, a.config)
+	if err == nil {
+		err = a.send(msg)
+	}
+	if err != nil {
+		a.releasePending(clOrdId)
+		return orig, err
+	}
+	amended.ClOrdId = utils.GetString(msg, constants.TagClOrdId)
+	a.trackReplace(amended)
+	amended, _ = a.Order(amended.ClOrdId)
+	return amended, nil
+}
+
+// RequestStatus sends an Order Status Request for a cached order and returns
+// the order as currently cached; the answer arrives as an ExecutionReport.
+func (a *FixApp) RequestStatus(clOrdId string) (model.OrderInfo, error) {
+	info, ok := a.Order(clOrdId)
+	if !ok {
+		return info, notFound("unknown ClOrdId %s (not in cache)", clOrdId)
+	}
+	msg := builder.BuildStatus(info.ClOrdId, info.OrderId, info.Side, info.Symbol, a.config)
+	return info, a.send(msg)
+}
+
+// Order returns the cached order with the given ClOrdID.
+func (a *FixApp) Order(clOrdId string) (model.OrderInfo, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	info, ok := a.orders[clOrdId]
+	return info, ok
+}
+
+// Orders returns every cached order ordered by ClOrdID.
+func (a *FixApp) Orders() []model.OrderInfo {
+	a.mu.RLock()
+	orders := make([]model.OrderInfo, 0, len(a.orders))
+	for _, o := range a.orders {
+		orders = append(orders, o)
+	}
+	a.mu.RUnlock()
+	sort.Slice(orders, func(i, j int) bool { return orders[i].ClOrdId < orders[j].ClOrdId })
+	return orders
+}
+
+// Fills returns the fills for symbol (all symbols when empty) executed at or
+// after since (all when zero), in ledger order.
+func (a *FixApp) Fills(symbol string, since time.Time) []model.Fill {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	fills := []model.Fill{}
+	for _, f := range a.fills {
+		if symbol != "" && !strings.EqualFold(f.Symbol, symbol) {
+			continue
+		}
+		if !since.IsZero() {
+			if t, ok := parseFixTime(f.TransactTime); ok && t.Before(since) {
+				continue
+			}
+		}
+		fills = append(fills, f)
+	}
+	return fills
+}
+
+// SendRfq validates req, runs the instrument and risk checks, sends the Quote
+// Request and returns the RFQ as tracked.
+func (a *FixApp) SendRfq(req builder.QuoteRequest) (model.QuoteRequestInfo, error) {
+	if req.Portfolio == "" {
+		req.Portfolio = a.config.PortfolioId
+	}
+	req.Side = strings.ToUpper(req.Side)
+	req.QtyType = strings.ToUpper(req.QtyType)
+	if err := req.Validate(); err != nil {
+		return model.QuoteRequestInfo{}, err
+	}
+	req = req.Normalize()
+
+	var err error
+	req.Symbol, req.Price, _, err = a.checkInstrument(req.Symbol, req.Side, req.QtyType, req.Qty, req.Price, "")
+	if err != nil {
+		return model.QuoteRequestInfo{}, err
+	}
+	if err := a.checkRisk(req.Symbol, req.QtyType, req.Qty, req.Price, false); err != nil {
+		return model.QuoteRequestInfo{}, err
+	}
+
+	msg, err := builder.BuildRfq(req, a.config)
+	if err != nil {
+		return model.QuoteRequestInfo{}, err
+	}
+	if err := a.send(msg); err != nil {
+		return model.QuoteRequestInfo{}, err
+	}
+	quoteReqId := utils.GetString(msg, constants.TagQuoteReqId)
+	a.trackRfq(model.QuoteRequestInfo{
+		QuoteReqId: quoteReqId,
+		Account:    req.Portfolio,
+		Side:       req.Side,
+		Symbol:     req.Symbol,
+		OrderQty:   req.Qty,
+		Price:      req.Price,
+		QtyType:    req.QtyType,
+	})
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.rfqs[quoteReqId], nil
+}
+
+// Rfqs returns every tracked RFQ, oldest first.
+func (a *FixApp) Rfqs() []model.QuoteRequestInfo {
+	a.mu.RLock()
+	rfqs := make([]model.QuoteRequestInfo, 0, len(a.rfqs))
+	for _, r := range a.rfqs {
+		rfqs = append(rfqs, r)
+	}
+	a.mu.RUnlock()
+	sort.Slice(rfqs, func(i, j int) bool { return rfqs[i].CreatedAt < rfqs[j].CreatedAt })
+	return rfqs
+}
+
+// Quotes returns every quote received, oldest first.
+func (a *FixApp) Quotes() []model.QuoteInfo {
+	a.mu.RLock()
+	quotes := make([]model.QuoteInfo, 0, len(a.quotes))
+	for _, q := range a.quotes {
+		quotes = append(quotes, q)
+	}
+	a.mu.RUnlock()
+	sort.Slice(quotes, func(i, j int) bool { return quotes[i].ReceivedAt < quotes[j].ReceivedAt })
+	return quotes
+}
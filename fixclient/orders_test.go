@@ -0,0 +1,96 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fixclient
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"prime-fix-go/model"
+
+	"github.com/quickfixgo/quickfix"
+)
+
+func TestConcurrentCancelAndReplaceSendOnce(t *testing.T) {
+	app := newTestApp(t)
+	clOrdId := placeWorking(t, app)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 8)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%2 == 0 {
+				_, errs[i] = app.CancelOrder(clOrdId)
+			} else {
+				_, errs[i] = app.ReplaceOrder(clOrdId, Amendment{Price: "49000"})
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	sent := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			sent++
+		case !errors.Is(err, ErrConflict):
+			t.Errorf("Expected ErrConflict, got %v", err)
+		}
+	}
+	if sent != 1 {
+		t.Errorf("Expected exactly one request sent, got %d", sent)
+	}
+}
+
+func TestUnsentCancelAndReplaceRollBack(t *testing.T) {
+	tests := []struct {
+		name    string
+		request func(app *FixApp, clOrdId string) error
+	}{
+		{"cancel", func(app *FixApp, clOrdId string) error {
+			_, err := app.CancelOrder(clOrdId)
+			return err
+		}},
+		{"replace", func(app *FixApp, clOrdId string) error {
+			_, err := app.ReplaceOrder(clOrdId, Amendment{Price: "49000"})
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApp(t)
+			clOrdId := placeWorking(t, app)
+			if err := quickfix.UnregisterSession(app.SessionId); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := tt.request(app, clOrdId); !errors.Is(err, ErrNotSent) {
+				t.Fatalf("Expected ErrNotSent, got %v", err)
+			}
+			info, _ := app.Order(clOrdId)
+			if info.Status != model.OrderStatusNew || info.PrevStatus != "" {
+				t.Errorf("Expected %s with no saved state, got %s (saved %q)", model.OrderStatusNew, info.Status, info.PrevStatus)
+			}
+			if orders := app.Orders(); len(orders) != 1 {
+				t.Errorf("Expected only the original order cached, got %d orders", len(orders))
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fixclient
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"prime-fix-go/amount"
+	"prime-fix-go/model"
+	"prime-fix-go/positions"
+)
+
+// rebuildPositionsLocked replays the fill ledger into the position tracker.
+// The caller must hold a.mu.
+func (a *FixApp) rebuildPositionsLocked() {
+	a.positions.Reset()
+	for _, f := range a.fills {
+		if err := a.positions.Apply(f, a.config.PortfolioId); err != nil {
+			log.Println("position err:", err)
+		}
+	}
+}
+
+// markFromQuote values positions at the quote's mid price, or at whichever
+// side was quoted.
+func (a *FixApp) markFromQuote(quote model.QuoteInfo) {
+	bid, bidErr := amount.Positive(quote.BidPx)
+	offer, offerErr := amount.Positive(quote.OfferPx)
+	switch {
+	case bidErr == nil && offerErr == nil:
+		a.positions.Mark(quote.Symbol, bid.Add(offer).Div(decimal.NewFromInt(2)))
+	case bidErr == nil:
+		a.positions.Mark(quote.Symbol, bid)
+	case offerErr == nil:
+		a.positions.Mark(quote.Symbol, offer)
+	}
+}
+
+func (a *FixApp) handlePositions(parts []string) {
+	mode := positions.ModeFifo
+	var symbol string
+	for _, arg := range parts[1:] {
+		switch strings.ToUpper(arg) {
+		case positions.ModeFifo, positions.ModeAvgCost:
+			mode = strings.ToUpper(arg)
+		default:
+			symbol = arg
+		}
+	}
+
+	var rows []positions.Position
+	for _, p := range a.positions.Positions() {
+		if symbol == "" || strings.EqualFold(p.Symbol, symbol) {
+			rows = append(rows, p)
+		}
+	}
+	if len(rows) == 0 {
+		fmt.Fprintln(a.out, "(no positions)")
+		return
+	}
+
+	fmt.Fprintf(a.out, "%-12s %-20s %14s %16s %14s %14s %14s %12s  %s\n",
+		"SYMBOL", "PORTFOLIO", "NET BASE", "NET QUOTE", "COST ("+mode+")", "REALIZED", "UNREALIZED", "FEES", "MARK")
+	var realized, unrealized decimal.Decimal
+	for _, p := range rows {
+		cost := p.AvgCost
+		if mode == positions.ModeFifo {
+			cost = p.FifoCost()
+		}
+		markText := "-"
+		unrealizedText := "-"
+		if mark, ok := a.positions.MarkFor(p.Symbol); ok {
+			u := p.Unrealized(mode, mark.Price)
+			unrealized = unrealized.Add(u)
+			unrealizedText = u.StringFixed(2)
+			markText = fmt.Sprintf("%s (%s)", amount.Format(mark.Price), mark.Source)
+		}
+		realized = realized.Add(p.Realized(mode))
+		fmt.Fprintf(a.out, "%-12s %-20s %14s %16s %14s %14s %14s %12s  %s\n",
+			p.Symbol, p.Portfolio, amount.Format(p.NetBase), p.NetQuote.StringFixed(2), cost.StringFixed(2),
+			p.Realized(mode).StringFixed(2), unrealizedText, p.Fees.StringFixed(2), markText)
+	}
+	fmt.Fprintf(a.out, "total realized %s, unrealized %s (%s)\n", realized.StringFixed(2), unrealized.StringFixed(2), mode)
+}
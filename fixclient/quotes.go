@@ -0,0 +1,324 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fixclient
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"prime-fix-go/amount"
+	"prime-fix-go/builder"
+	"prime-fix-go/constants"
+	"prime-fix-go/events"
+	"prime-fix-go/model"
+	"prime-fix-go/quotepolicy"
+	"prime-fix-go/utils"
+
+	"github.com/quickfixgo/quickfix"
+)
+
+// handleQuote stores a received quote until it is accepted, passed or
+// expires. With AutoAcceptQuotes set, a quote the auto-accept policy approves
+// is accepted straight away and any other is held for a manual decision.
+func (a *FixApp) handleQuote(msg *quickfix.Message) {
+	quote := model.QuoteInfo{
+		QuoteId:        utils.GetString(msg, constants.TagQuoteId),
+		QuoteReqId:     utils.GetString(msg, constants.TagQuoteReqId),
+		Account:        utils.GetString(msg, constants.TagAccount),
+		Symbol:         utils.GetString(msg, constants.TagSymbol),
+		BidPx:          utils.GetString(msg, constants.TagBidPx),
+		OfferPx:        utils.GetString(msg, constants.TagOfferPx),
+		BidSize:        utils.GetString(msg, constants.TagBidSize),
+		OfferSize:      utils.GetString(msg, constants.TagOfferSize),
+		ValidUntilTime: utils.GetString(msg, constants.TagValidUntilTime),
+		Status:         model.QuoteStatusOpen,
+		ReceivedAt:     time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if quote.QuoteId == "" {
+		return
+	}
+
+	log.Printf("✓ received quote %s for request %s", quote.QuoteId, quote.QuoteReqId)
+	a.markFromQuote(quote)
+
+	a.mu.Lock()
+	rfq, hasRfq := a.rfqs[quote.QuoteReqId]
+	_, price, qty, _ := quoteSide(quote, rfq.Side)
+	a.putQuoteLocked(quote)
+	a.updateRfqLocked(quote.QuoteReqId, model.RfqStatusQuoted, func(r *model.QuoteRequestInfo) {
+		r.QuoteId = quote.QuoteId
+		r.QuotePx = price
+		r.QuoteQty = qty
+		r.ValidUntilTime = quote.ValidUntilTime
+	})
+	a.mu.Unlock()
+
+	if quote.BidPx != "" {
+		fmt.Fprintf(a.out, "Quote: Bid %s @ %s (valid until %s)\n", quote.BidSize, quote.BidPx, quote.ValidUntilTime)
+	}
+	if quote.OfferPx != "" {
+		fmt.Fprintf(a.out, "Quote: Offer %s @ %s (valid until %s)\n", quote.OfferSize, quote.OfferPx, quote.ValidUntilTime)
+	}
+
+	if a.config.AutoAcceptQuotes {
+		decision := a.evaluateQuote(quote, rfq, hasRfq)
+		if decision.Accept {
+			log.Printf("✓ auto-accept quote %s: %s", quote.QuoteId, decision.Reason)
+			_, err := a.AcceptQuote(quote.QuoteId)
+			if err == nil {
+				return
+			}
+			log.Printf("✗ auto-accept of quote %s failed: %v", quote.QuoteId, err)
+		} else {
+			log.Printf("… holding quote %s for manual decision: %s", quote.QuoteId, decision.Reason)
+		}
+	}
+
+	expiry, ok := quoteExpiry(quote)
+	if !ok {
+		a.notify(fmt.Sprintf("Quote %s: accept %s or pass %s", quote.QuoteId, quote.QuoteId, quote.QuoteId))
+		return
+	}
+	a.notify(fmt.Sprintf("Quote %s expires in %s: accept %s or pass %s",
+		quote.QuoteId, formatRemaining(time.Until(expiry)), quote.QuoteId, quote.QuoteId))
+	time.AfterFunc(time.Until(expiry), func() { a.expireQuote(quote.QuoteId) })
+}
+
+// evaluateQuote applies the auto-accept policy to a quote and the RFQ it answers.
+func (a *FixApp) evaluateQuote(quote model.QuoteInfo, rfq model.QuoteRequestInfo, hasRfq bool) quotepolicy.Decision {
+	if !hasRfq {
+		return quotepolicy.Decision{Reason: "quote does not answer a known RFQ"}
+	}
+	_, price, qty, ok := quoteSide(quote, rfq.Side)
+	if !ok {
+		return quotepolicy.Decision{Reason: "quote has no price on the " + rfq.Side + " side"}
+	}
+	q := quotepolicy.Quote{Symbol: quote.Symbol, Side: rfq.Side}
+	var err error
+	if q.Price, err = amount.Parse(price); err != nil {
+		return quotepolicy.Decision{Reason: fmt.Sprintf("quote has no valid price: %v", err)}
+	}
+	if q.Qty, err = amount.Parse(qty); err != nil {
+		return quotepolicy.Decision{Reason: fmt.Sprintf("quote has no valid size: %v", err)}
+	}
+	if q.Limit, err = amount.Parse(rfq.Price); err != nil {
+		return quotepolicy.Decision{Reason: fmt.Sprintf("RFQ has no valid limit price: %v", err)}
+	}
+	q.ValidUntil, _ = quoteExpiry(quote)
+
+	policy := a.quotePolicy
+	if policy == nil {
+		policy = quotepolicy.Default()
+	}
+	return policy.Evaluate(q, time.Now())
+}
+
+// SetQuotePolicy installs the rules AutoAcceptQuotes applies to each quote.
+func (a *FixApp) SetQuotePolicy(policy *quotepolicy.Policy) {
+	a.quotePolicy = policy
+}
+
+// expireQuote marks a quote that was not accepted as EXPIRED, along with the
+// RFQ it answered.
+func (a *FixApp) expireQuote(quoteId string) {
+	a.mu.Lock()
+	quote, ok := a.quotes[quoteId]
+	if !ok || (quote.Status != model.QuoteStatusOpen && quote.Status != model.QuoteStatusPassed) {
+		a.mu.Unlock()
+		return
+	}
+	wasOpen := quote.Status == model.QuoteStatusOpen
+	if wasOpen {
+		quote.Status = model.QuoteStatusExpired
+		a.putQuoteLocked(quote)
+	}
+	if rfq, ok := a.rfqs[quote.QuoteReqId]; ok && rfq.QuoteId == quoteId && rfq.Status == model.RfqStatusQuoted {
+		a.updateRfqLocked(rfq.QuoteReqId, model.RfqStatusExpired, nil)
+	}
+	a.mu.Unlock()
+	if wasOpen {
+		a.notify(fmt.Sprintf("⌛ quote %s expired", quoteId))
+	}
+}
+
+// AcceptQuote sends a Previously Quoted order for an open quote and returns
+// the order as cached.
+func (a *FixApp) AcceptQuote(quoteId string) (model.OrderInfo, error) {
+	a.mu.RLock()
+	quote, ok := a.quotes[quoteId]
+	a.mu.RUnlock()
+	if !ok {
+		return model.OrderInfo{}, notFound("unknown quote %s", quoteId)
+	}
+	if quote.Status != model.QuoteStatusOpen {
+		return model.OrderInfo{}, conflict("quote %s is %s", quoteId, strings.ToLower(quote.Status))
+	}
+	if expiry, ok := quoteExpiry(quote); ok && !time.Now().Before(expiry) {
+		a.expireQuote(quoteId)
+		return model.OrderInfo{}, conflict("quote %s expired at %s", quoteId, quote.ValidUntilTime)
+	}
+	a.mu.RLock()
+	rfqSide := a.rfqs[quote.QuoteReqId].Side
+	a.mu.RUnlock()
+	side, price, qty, ok := quoteSide(quote, rfqSide)
+	if !ok {
+		return model.OrderInfo{}, fmt.Errorf("quote %s has no valid price for the RFQ side", quoteId)
+	}
+	if err := a.checkRisk(quote.Symbol, "BASE", qty, price, false); err != nil {
+		return model.OrderInfo{}, err
+	}
+
+	// Claim the quote before sending so a concurrent accept, pass or expiry
+	// cannot act on it twice.
+	if !a.setQuoteStatus(quoteId, model.QuoteStatusOpen, model.QuoteStatusAccepted) {
+		return model.OrderInfo{}, conflict("quote %s is no longer open", quoteId)
+	}
+	acceptMsg := builder.BuildAcceptQuote(quote.QuoteId, quote.Symbol, side, qty, price, a.config.PortfolioId, a.config)
+	if err := a.send(acceptMsg); err != nil {
+		a.setQuoteStatus(quoteId, model.QuoteStatusAccepted, model.QuoteStatusOpen)
+		return model.OrderInfo{}, err
+	}
+
+	clOrdId := utils.GetString(acceptMsg, constants.TagClOrdId)
+	a.mu.Lock()
+	a.updateRfqLocked(quote.QuoteReqId, model.RfqStatusAccepted, func(r *model.QuoteRequestInfo) {
+		r.ClOrdId = clOrdId
+	})
+	a.mu.Unlock()
+	a.trackOrder(model.OrderInfo{
+		ClOrdId:    clOrdId,
+		Side:       utils.GetString(acceptMsg, constants.TagSide),
+		Symbol:     quote.Symbol,
+		Quantity:   qty,
+		LimitPrice: price,
+		OrdType:    constants.OrdTypeRfq,
+		QtyType:    "BASE",
+		QuoteId:    quote.QuoteId,
+		QuoteReqId: quote.QuoteReqId,
+	})
+	log.Printf("✓ accepting quote %s with order %s: %s %s %s @ %s", quote.QuoteId, clOrdId, side, qty, quote.Symbol, price)
+	info, _ := a.Order(clOrdId)
+	return info, nil
+}
+
+// setQuoteStatus moves a quote from one status to another, reporting false if
+// it was not in from.
+func (a *FixApp) setQuoteStatus(quoteId, from, to string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	quote, ok := a.quotes[quoteId]
+	if !ok || quote.Status != from {
+		return false
+	}
+	quote.Status = to
+	a.putQuoteLocked(quote)
+	return true
+}
+
+// putQuoteLocked stores a quote and publishes it. The caller must hold a.mu.
+func (a *FixApp) putQuoteLocked(quote model.QuoteInfo) {
+	a.quotes[quote.QuoteId] = quote
+	a.events.Publish(events.TypeQuote, quote.Symbol, quote)
+}
+
+// quoteSide returns the side, price and size accepting the quote trades:
+// hitting the bid sells and lifting the offer buys. rfqSide picks the side the
+// RFQ asked for; when it is unknown the bid is preferred.
+func quoteSide(quote model.QuoteInfo, rfqSide string) (side, price, qty string, ok bool) {
+	switch strings.ToUpper(rfqSide) {
+	case constants.SideBuy:
+		return constants.SideBuy, quote.OfferPx, quote.OfferSize, quote.OfferPx != ""
+	case constants.SideSell:
+		return constants.SideSell, quote.BidPx, quote.BidSize, quote.BidPx != ""
+	}
+	if quote.BidPx != "" {
+		return constants.SideSell, quote.BidPx, quote.BidSize, true
+	}
+	if quote.OfferPx != "" {
+		return constants.SideBuy, quote.OfferPx, quote.OfferSize, true
+	}
+	return "", "", "", false
+}
+
+func quoteExpiry(quote model.QuoteInfo) (time.Time, bool) {
+	return parseFixTime(quote.ValidUntilTime)
+}
+
+func formatRemaining(d time.Duration) string {
+	if d <= 0 {
+		return "0s"
+	}
+	return d.Round(time.Second).String()
+}
+
+func (a *FixApp) handleQuotes() {
+	a.mu.RLock()
+	quotes := make([]model.QuoteInfo, 0, len(a.quotes))
+	rfqSides := make(map[string]string, len(a.quotes))
+	for _, q := range a.quotes {
+		quotes = append(quotes, q)
+		rfqSides[q.QuoteId] = a.rfqs[q.QuoteReqId].Side
+	}
+	a.mu.RUnlock()
+
+	if len(quotes) == 0 {
+		fmt.Fprintln(a.out, "(no quotes received)")
+		return
+	}
+	sort.Slice(quotes, func(i, j int) bool { return quotes[i].ReceivedAt < quotes[j].ReceivedAt })
+	for _, q := range quotes {
+		side, price, qty, _ := quoteSide(q, rfqSides[q.QuoteId])
+		remaining := "-"
+		if expiry, ok := quoteExpiry(q); ok && q.Status == model.QuoteStatusOpen {
+			remaining = formatRemaining(time.Until(expiry)) + " left"
+		}
+		fmt.Fprintf(a.out, "%-24s (req %s) %s %s %s @ %s  %-8s %s\n",
+			q.QuoteId, orDash(q.QuoteReqId), orDash(side), q.Symbol, orDash(qty), orDash(price),
+			q.Status, remaining)
+	}
+}
+
+func (a *FixApp) handleAccept(parts []string) (pendingAck, bool) {
+	if len(parts) < 2 {
+		fmt.Fprintln(a.out, "usage: accept <QuoteId>")
+		return pendingAck{}, false
+	}
+	info, err := a.AcceptQuote(parts[1])
+	if err != nil {
+		a.printError(err)
+		return pendingAck{}, false
+	}
+	return pendingAck{sentOrder, info.ClOrdId}, true
+}
+
+func (a *FixApp) handlePass(parts []string) bool {
+	if len(parts) < 2 {
+		fmt.Fprintln(a.out, "usage: pass <QuoteId>")
+		return false
+	}
+	quoteId := parts[1]
+	if !a.setQuoteStatus(quoteId, model.QuoteStatusOpen, model.QuoteStatusPassed) {
+		fmt.Fprintf(a.out, "error: no open quote %s\n", quoteId)
+		return false
+	}
+	fmt.Fprintf(a.out, "Passed on quote %s\n", quoteId)
+	return true
+}
@@ -0,0 +1,112 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fixclient
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"prime-fix-go/builder"
+	"prime-fix-go/constants"
+	"prime-fix-go/model"
+
+	"github.com/quickfixgo/quickfix"
+)
+
+// sendBuyRfq sends an RFQ to buy 1 BTC-USD at up to 50000 and returns its
+// QuoteReqID.
+func sendBuyRfq(t *testing.T, app *FixApp) string {
+	t.Helper()
+	rfq, err := app.SendRfq(builder.QuoteRequest{Symbol: "BTC-USD", Side: "BUY", QtyType: "BASE", Qty: "1", Price: "50000"})
+	if err != nil {
+		t.Fatalf("SendRfq returned error: %v", err)
+	}
+	return rfq.QuoteReqId
+}
+
+func offer(quoteReqId, quoteId, px string, validFor time.Duration) *quickfix.Message {
+	return inbound(constants.MsgTypeQuote, map[quickfix.Tag]string{
+		constants.TagQuoteId:        quoteId,
+		constants.TagQuoteReqId:     quoteReqId,
+		constants.TagSymbol:         "BTC-USD",
+		constants.TagOfferPx:        px,
+		constants.TagOfferSize:      "1",
+		constants.TagValidUntilTime: time.Now().Add(validFor).UTC().Format(constants.FixTimeFormat),
+	})
+}
+
+func quoteStatus(app *FixApp, quoteId string) string {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	return app.quotes[quoteId].Status
+}
+
+func TestQuoteIsHeldUnlessPolicyAccepts(t *testing.T) {
+	tests := []struct {
+		name       string
+		autoAccept bool
+		px         string
+		wantQuote  string
+		wantRfq    string
+	}{
+		{"manual", false, "49900", model.QuoteStatusOpen, model.RfqStatusQuoted},
+		{"within limit", true, "49900", model.QuoteStatusAccepted, model.RfqStatusAccepted},
+		{"above limit", true, "50100", model.QuoteStatusOpen, model.RfqStatusQuoted},
+		{"malformed price", true, "4990O", model.QuoteStatusOpen, model.RfqStatusQuoted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApp(t)
+			app.config.AutoAcceptQuotes = tt.autoAccept
+			quoteReqId := sendBuyRfq(t, app)
+
+			deliver(app, offer(quoteReqId, "q-1", tt.px, time.Minute))
+
+			if got := quoteStatus(app, "q-1"); got != tt.wantQuote {
+				t.Errorf("Expected quote %s, got %s", tt.wantQuote, got)
+			}
+			rfq, _ := app.rfq(quoteReqId)
+			if rfq.Status != tt.wantRfq {
+				t.Errorf("Expected RFQ %s, got %s", tt.wantRfq, rfq.Status)
+			}
+			if tt.wantQuote == model.QuoteStatusAccepted {
+				order, ok := app.Order(rfq.ClOrdId)
+				if !ok || order.QuoteId != "q-1" || order.Status != model.OrderStatusPendingNew {
+					t.Errorf("Expected a %s order for the quote, got %+v", model.OrderStatusPendingNew, order)
+				}
+			}
+		})
+	}
+}
+
+func TestHeldQuoteExpires(t *testing.T) {
+	app := newTestApp(t)
+	quoteReqId := sendBuyRfq(t, app)
+
+	deliver(app, offer(quoteReqId, "q-1", "49900", 50*time.Millisecond))
+	err := app.waitFor(2*time.Second, func() bool { return quoteStatus(app, "q-1") == model.QuoteStatusExpired })
+	if err != nil {
+		t.Fatalf("Expected quote to expire: %v", err)
+	}
+	if rfq, _ := app.rfq(quoteReqId); rfq.Status != model.RfqStatusExpired {
+		t.Errorf("Expected RFQ %s, got %s", model.RfqStatusExpired, rfq.Status)
+	}
+	if _, err := app.AcceptQuote("q-1"); !errors.Is(err, ErrConflict) {
+		t.Errorf("Expected accepting an expired quote to conflict, got %v", err)
+	}
+}
@@ -0,0 +1,348 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fixclient
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"prime-fix-go/constants"
+	"prime-fix-go/events"
+	"prime-fix-go/model"
+	"prime-fix-go/utils"
+
+	"github.com/quickfixgo/quickfix"
+)
+
+// outboundRefTtl bounds how long an outbound message can still be matched to
+// a Reject or Business Message Reject that names its MsgSeqNum.
+const outboundRefTtl = 5 * time.Minute
+
+// outboundRef identifies the order or RFQ carried by an outbound message.
+type outboundRef struct {
+	MsgType     string
+	ClOrdId     string
+	OrigClOrdId string
+	QuoteReqId  string
+	SentAt      time.Time
+}
+
+func (r outboundRef) id() string {
+	if r.QuoteReqId != "" {
+		return r.QuoteReqId
+	}
+	return r.ClOrdId
+}
+
+var cxlRejReasons = map[string]string{
+	"0": "too late to cancel",
+	"1": "unknown order",
+	"2": "broker option",
+	"3": "already pending cancel or replace",
+}
+
+var sessionRejectReasons = map[string]string{
+	"0":  "invalid tag number",
+	"1":  "required tag missing",
+	"2":  "tag not defined for this message type",
+	"3":  "undefined tag",
+	"4":  "tag specified without a value",
+	"5":  "value is incorrect (out of range) for this tag",
+	"6":  "incorrect data format for value",
+	"7":  "decryption problem",
+	"8":  "signature problem",
+	"9":  "CompID problem",
+	"10": "SendingTime accuracy problem",
+	"11": "invalid MsgType",
+	"12": "XML validation error",
+	"13": "tag appears more than once",
+	"14": "tag specified out of required order",
+	"15": "repeating group fields out of order",
+	"16": "incorrect NumInGroup count for repeating group",
+	"17": "non-data value includes field delimiter",
+	"99": "other",
+}
+
+var businessRejectReasons = map[string]string{
+	"0": "other",
+	"1": "unknown ID",
+	"2": "unknown security",
+	"3": "unsupported message type",
+	"4": "application not available",
+	"5": "conditionally required field missing",
+	"6": "not authorized",
+	"7": "DeliverTo firm not available at this time",
+}
+
+func describeCode(descriptions map[string]string, code string) string {
+	if desc, ok := descriptions[code]; ok {
+		return desc
+	}
+	if code == "" {
+		return "unspecified"
+	}
+	return "code " + code
+}
+
+// recordOutbound remembers which order or RFQ an outbound application message
+// carried, keyed by its MsgSeqNum.
+func (a *FixApp) recordOutbound(msg *quickfix.Message) {
+	seqNum, err := msg.Header.GetInt(constants.TagMsgSeqNum)
+	if err != nil {
+		return
+	}
+	msgType, _ := msg.Header.GetString(constants.TagMsgType)
+	ref := outboundRef{
+		MsgType:     msgType,
+		ClOrdId:     utils.GetString(msg, constants.TagClOrdId),
+		OrigClOrdId: utils.GetString(msg, constants.TagOrigClOrdId),
+		QuoteReqId:  utils.GetString(msg, constants.TagQuoteReqId),
+		SentAt:      time.Now(),
+	}
+	if ref.id() == "" {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for seq, r := range a.outbound {
+		if time.Since(r.SentAt) > outboundRefTtl {
+			delete(a.outbound, seq)
+		}
+	}
+	a.outbound[seqNum] = ref
+}
+
+func (a *FixApp) lookupOutbound(seqNum int) (outboundRef, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	ref, ok := a.outbound[seqNum]
+	if !ok || time.Since(ref.SentAt) > outboundRefTtl {
+		return outboundRef{}, false
+	}
+	return ref, true
+}
+
+// handleSessionReject attributes a session-level Reject (35=3) to the order
+// or RFQ whose message it refers to.
+func (a *FixApp) handleSessionReject(msg *quickfix.Message) {
+	refSeqNum := utils.GetString(msg, constants.TagRefSeqNum)
+	refTagId := utils.GetString(msg, constants.TagRefTagId)
+	refMsgType := utils.GetString(msg, constants.TagRefMsgType)
+	reason := describeCode(sessionRejectReasons, utils.GetString(msg, constants.TagSessionRejectReason))
+	if refTagId != "" {
+		reason += " (tag " + refTagId + ")"
+	}
+	if text := utils.GetString(msg, constants.TagText); text != "" {
+		reason += ": " + text
+	}
+
+	seq, _ := strconv.Atoi(refSeqNum)
+	ref, ok := a.lookupOutbound(seq)
+	if !ok {
+		a.publishReject("session", outboundRef{MsgType: refMsgType}, reason)
+		a.notify(fmt.Sprintf("✗ message %s (seq %s) rejected: %s", orDash(refMsgType), orDash(refSeqNum), reason))
+		return
+	}
+	a.rejectOutbound(ref, reason)
+	a.publishReject("session", ref, reason)
+	a.notify(fmt.Sprintf("✗ %s %s rejected: %s", describeMsgType(ref.MsgType), ref.id(), reason))
+}
+
+// handleBusinessReject attributes a Business Message Reject (35=j) using
+// BusinessRejectRefID when present and the outbound MsgSeqNum otherwise.
+func (a *FixApp) handleBusinessReject(msg *quickfix.Message) {
+	refSeqNum := utils.GetString(msg, constants.TagRefSeqNum)
+	refMsgType := utils.GetString(msg, constants.TagRefMsgType)
+	refId := utils.GetString(msg, constants.TagBusinessRejectRefId)
+	reason := describeCode(businessRejectReasons, utils.GetString(msg, constants.TagBusinessRejectReason))
+	if text := utils.GetString(msg, constants.TagText); text != "" {
+		reason += ": " + text
+	}
+
+	seq, _ := strconv.Atoi(refSeqNum)
+	ref, ok := a.lookupOutbound(seq)
+	if !ok && refId != "" {
+		ref, ok = outboundRef{MsgType: refMsgType, ClOrdId: refId}, true
+		if refMsgType == constants.MsgTypeQuoteReq {
+			ref = outboundRef{MsgType: refMsgType, QuoteReqId: refId}
+		}
+	}
+	if !ok {
+		a.publishReject("business", outboundRef{MsgType: refMsgType}, reason)
+		a.notify(fmt.Sprintf("✗ message %s (seq %s) rejected: %s", orDash(refMsgType), orDash(refSeqNum), reason))
+		return
+	}
+	a.rejectOutbound(ref, reason)
+	a.publishReject("business", ref, reason)
+	a.notify(fmt.Sprintf("✗ %s %s rejected: %s", describeMsgType(ref.MsgType), ref.id(), reason))
+}
+
+// publishReject publishes a reject event for the order or RFQ ref names.
+func (a *FixApp) publishReject(source string, ref outboundRef, reason string) {
+	a.mu.RLock()
+	symbol := a.orders[ref.ClOrdId].Symbol
+	if ref.QuoteReqId != "" {
+		symbol = a.rfqs[ref.QuoteReqId].Symbol
+	}
+	a.mu.RUnlock()
+	a.events.Publish(events.TypeReject, symbol, events.Reject{
+		Source:     source,
+		RefMsgType: ref.MsgType,
+		ClOrdId:    ref.ClOrdId,
+		QuoteReqId: ref.QuoteReqId,
+		Reason:     reason,
+	})
+}
+
+// rejectOutbound applies a reject to the cached order it refers to: a new
+// order or RFQ becomes REJECTED, while a rejected cancel or replace returns the
+// original order to the state it held before the request.
+func (a *FixApp) rejectOutbound(ref outboundRef, reason string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	switch ref.MsgType {
+	case constants.MsgTypeCancel:
+		a.restorePendingLocked(ref.OrigClOrdId, "", now, nil)
+	case constants.MsgTypeReplace:
+		a.restorePendingLocked(ref.OrigClOrdId, "", now, nil)
+		a.markRejectedLocked(ref.ClOrdId, reason, now)
+	case constants.MsgTypeQuoteReq:
+		a.updateRfqLocked(ref.QuoteReqId, model.RfqStatusRejected, func(r *model.QuoteRequestInfo) {
+			r.Text = reason
+		})
+	case constants.MsgTypeStatus:
+		// Nothing is cached for a status request; the notification is enough.
+		return
+	default:
+		a.markRejectedLocked(ref.ClOrdId, reason, now)
+	}
+}
+
+// restorePendingLocked returns an order in PENDING_CANCEL or PENDING_REPLACE to
+// status, or to the status saved when the request was sent if status is "",
+// applying update, if any, before the order is saved. The caller must hold a.mu.
+func (a *FixApp) restorePendingLocked(clOrdId, status, now string, update func(*model.OrderInfo)) (model.OrderInfo, bool) {
+	info, ok := a.orders[clOrdId]
+	if !ok {
+		return info, false
+	}
+	if status == "" || status == model.OrderStatusPendingCancel || status == model.OrderStatusPendingReplace {
+		status = info.PrevStatus
+	}
+	if status != "" && (info.Status == model.OrderStatusPendingCancel || info.Status == model.OrderStatusPendingReplace) {
+		info.Status = status
+	}
+	info.PrevStatus = ""
+	info.UpdatedAt = now
+	if update != nil {
+		update(&info)
+	}
+	a.putOrderLocked(info)
+	return info, true
+}
+
+// markRejectedLocked moves a cached order to REJECTED. The caller must hold a.mu.
+func (a *FixApp) markRejectedLocked(clOrdId, reason, now string) {
+	info, ok := a.orders[clOrdId]
+	if !ok {
+		return
+	}
+	if err := info.Transition(model.OrderStatusRejected); err != nil {
+		info.IllegalTransition = err.Error()
+		log.Printf("⚠ %s: %v", clOrdId, err)
+	}
+	info.Text = reason
+	info.UpdatedAt = now
+	a.putOrderLocked(info)
+}
+
+func describeMsgType(msgType string) string {
+	switch msgType {
+	case constants.MsgTypeNew:
+		return "order"
+	case constants.MsgTypeCancel:
+		return "cancel"
+	case constants.MsgTypeReplace:
+		return "replace"
+	case constants.MsgTypeStatus:
+		return "status request"
+	case constants.MsgTypeQuoteReq:
+		return "quote request"
+	}
+	return "message " + msgType
+}
+
+// handleCancelReject restores an order whose cancel or cancel/replace was
+// rejected to the state it held before the request was sent.
+func (a *FixApp) handleCancelReject(msg *quickfix.Message) {
+	clOrdId := utils.GetString(msg, constants.TagClOrdId)
+	origClOrdId := utils.GetString(msg, constants.TagOrigClOrdId)
+	reason := utils.GetString(msg, constants.TagCxlRejReason)
+	responseTo := utils.GetString(msg, constants.TagCxlRejResponseTo)
+	text := utils.GetString(msg, constants.TagText)
+	status := model.OrderStatusFromFix(utils.GetString(msg, constants.TagOrdStatus), "")
+
+	request := "cancel"
+	if responseTo == constants.CxlRejResponseToReplace {
+		request = "replace"
+	}
+
+	a.mu.Lock()
+	now := time.Now().UTC().Format(time.RFC3339)
+	// OrdStatus on the reject is the order's current state; the state saved
+	// when the request was sent is the fallback.
+	orig, ok := a.restorePendingLocked(origClOrdId, status, now, func(o *model.OrderInfo) {
+		o.CxlRejReason = reason
+		o.CxlRejResponseTo = responseTo
+		o.Text = text
+	})
+	if responseTo == constants.CxlRejResponseToReplace {
+		if amended, found := a.orders[clOrdId]; found {
+			_ = amended.Transition(model.OrderStatusRejected)
+			amended.CxlRejReason = reason
+			amended.CxlRejResponseTo = responseTo
+			amended.Text = text
+			amended.UpdatedAt = now
+			a.putOrderLocked(amended)
+		}
+	}
+	a.mu.Unlock()
+
+	refMsgType := constants.MsgTypeCancel
+	if responseTo == constants.CxlRejResponseToReplace {
+		refMsgType = constants.MsgTypeReplace
+	}
+	rejectReason := describeCode(cxlRejReasons, reason)
+	if text != "" {
+		rejectReason += ": " + text
+	}
+	a.publishReject("cancel", outboundRef{MsgType: refMsgType, ClOrdId: origClOrdId}, rejectReason)
+
+	line := "✗ " + request + " rejected for " + origClOrdId + ": " + describeCode(cxlRejReasons, reason)
+	if text != "" {
+		line += " (" + text + ")"
+	}
+	if ok {
+		line += ", order is " + orDash(orig.Status)
+	}
+	a.notify(line)
+}
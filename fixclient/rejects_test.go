@@ -0,0 +1,197 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fixclient
+
+import (
+	"strconv"
+	"testing"
+
+	"prime-fix-go/constants"
+	"prime-fix-go/events"
+	"prime-fix-go/model"
+
+	"github.com/quickfixgo/quickfix"
+)
+
+func TestCancelRejectRestoresOrder(t *testing.T) {
+	tests := []struct {
+		name       string
+		replace    bool
+		ordStatus  string
+		wantStatus string
+	}{
+		{"cancel, saved state", false, "", model.OrderStatusNew},
+		{"cancel, reported state", false, constants.OrdStatusPartiallyFilled, model.OrderStatusPartiallyFilled},
+		{"replace, saved state", true, "", model.OrderStatusNew},
+		{"replace, reported pending state", true, constants.OrdStatusPendingReplace, model.OrderStatusNew},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApp(t)
+			clOrdId := placeWorking(t, app)
+
+			responseTo := constants.CxlRejResponseToCancel
+			requestId := ""
+			pending := model.OrderStatusPendingCancel
+			if tt.replace {
+				amended, err := app.ReplaceOrder(clOrdId, Amendment{Price: "49000"})
+				if err != nil {
+					t.Fatalf("ReplaceOrder returned error: %v", err)
+				}
+				responseTo = constants.CxlRejResponseToReplace
+				requestId = amended.ClOrdId
+				pending = model.OrderStatusPendingReplace
+			} else if _, err := app.CancelOrder(clOrdId); err != nil {
+				t.Fatalf("CancelOrder returned error: %v", err)
+			}
+			if info, _ := app.Order(clOrdId); info.Status != pending || info.PrevStatus != model.OrderStatusNew {
+				t.Fatalf("Expected %s from %s, got %s from %s", pending, model.OrderStatusNew, info.Status, info.PrevStatus)
+			}
+
+			fields := map[quickfix.Tag]string{
+				constants.TagClOrdId:          requestId,
+				constants.TagOrigClOrdId:      clOrdId,
+				constants.TagCxlRejResponseTo: responseTo,
+				constants.TagCxlRejReason:     "1",
+				constants.TagText:             "too late",
+			}
+			if tt.ordStatus != "" {
+				fields[constants.TagOrdStatus] = tt.ordStatus
+			}
+			sub := app.Events().Subscribe(events.Filter{Types: []string{events.TypeOrder}}, 0)
+			deliver(app, inbound(constants.MsgTypeCancelReject, fields))
+			sub.Close()
+			var published []model.OrderInfo
+			for e := range sub.Events {
+				if info := e.Data.(model.OrderInfo); info.ClOrdId == clOrdId {
+					published = append(published, info)
+				}
+			}
+			if len(published) != 1 || published[0].Text != "too late" {
+				t.Errorf("Expected one order event carrying the reject, got %+v", published)
+			}
+
+			info, _ := app.Order(clOrdId)
+			if info.Status != tt.wantStatus || info.PrevStatus != "" {
+				t.Errorf("Expected %s with no saved state, got %s (saved %q)", tt.wantStatus, info.Status, info.PrevStatus)
+			}
+			if info.Text != "too late" || info.CxlRejResponseTo != responseTo {
+				t.Errorf("Expected the reject recorded on the order, got text %q response to %q", info.Text, info.CxlRejResponseTo)
+			}
+			if tt.replace {
+				if amended, _ := app.Order(requestId); amended.Status != model.OrderStatusRejected {
+					t.Errorf("Expected amended order %s, got %s", model.OrderStatusRejected, amended.Status)
+				}
+			}
+		})
+	}
+}
+
+// outboundSeqNum returns the MsgSeqNum recorded for the last message of
+// msgType sent for clOrdId.
+func outboundSeqNum(t *testing.T, app *FixApp, msgType, clOrdId string) string {
+	t.Helper()
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	last := 0
+	for seq, ref := range app.outbound {
+		if ref.MsgType == msgType && (ref.ClOrdId == clOrdId || ref.OrigClOrdId == clOrdId) && seq > last {
+			last = seq
+		}
+	}
+	if last == 0 {
+		t.Fatalf("No %s recorded for %s", msgType, clOrdId)
+	}
+	return strconv.Itoa(last)
+}
+
+func TestRejectsAreMatchedToOutboundMessages(t *testing.T) {
+	tests := []struct {
+		name       string
+		msgType    string
+		ack        bool
+		cancel     bool
+		fields     func(seq, clOrdId string) map[quickfix.Tag]string
+		wantStatus string
+	}{
+		{
+			name:    "session reject of the order",
+			msgType: constants.MsgTypeReject,
+			fields: func(seq, _ string) map[quickfix.Tag]string {
+				return map[quickfix.Tag]string{constants.TagRefSeqNum: seq, constants.TagSessionRejectReason: "5"}
+			},
+			wantStatus: model.OrderStatusRejected,
+		},
+		{
+			name:    "session reject of an unknown message",
+			msgType: constants.MsgTypeReject,
+			fields: func(string, string) map[quickfix.Tag]string {
+				return map[quickfix.Tag]string{constants.TagRefSeqNum: "999", constants.TagSessionRejectReason: "5"}
+			},
+			wantStatus: model.OrderStatusPendingNew,
+		},
+		{
+			name:    "business reject of the cancel",
+			msgType: constants.MsgTypeBusinessReject,
+			ack:     true,
+			cancel:  true,
+			fields: func(seq, _ string) map[quickfix.Tag]string {
+				return map[quickfix.Tag]string{constants.TagRefSeqNum: seq, constants.TagBusinessRejectReason: "3"}
+			},
+			wantStatus: model.OrderStatusNew,
+		},
+		{
+			name:    "business reject by reference ID",
+			msgType: constants.MsgTypeBusinessReject,
+			fields: func(_, clOrdId string) map[quickfix.Tag]string {
+				return map[quickfix.Tag]string{
+					constants.TagRefSeqNum:            "999",
+					constants.TagRefMsgType:           constants.MsgTypeNew,
+					constants.TagBusinessRejectRefId:  clOrdId,
+					constants.TagBusinessRejectReason: "1",
+				}
+			},
+			wantStatus: model.OrderStatusRejected,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApp(t)
+			clOrdId := placeOrder(t, app)
+			seq := outboundSeqNum(t, app, constants.MsgTypeNew, clOrdId)
+			if tt.ack {
+				deliver(app, execReport(clOrdId, constants.ExecTypeNew, constants.OrdStatusNew, nil))
+			}
+			if tt.cancel {
+				if _, err := app.CancelOrder(clOrdId); err != nil {
+					t.Fatalf("CancelOrder returned error: %v", err)
+				}
+				seq = outboundSeqNum(t, app, constants.MsgTypeCancel, clOrdId)
+			}
+
+			deliver(app, inbound(tt.msgType, tt.fields(seq, clOrdId)))
+
+			info, _ := app.Order(clOrdId)
+			if info.Status != tt.wantStatus {
+				t.Errorf("Expected %s, got %s", tt.wantStatus, info.Status)
+			}
+			if tt.wantStatus == model.OrderStatusRejected && info.Text == "" {
+				t.Error("Expected the reject reason on the order")
+			}
+		})
+	}
+}
@@ -17,93 +17,137 @@
 package fixclient
 
 import (
+	"errors"
 	"fmt"
-	"github.com/quickfixgo/quickfix"
+	"io"
 	"prime-fix-go/builder"
-	"strconv"
+	"prime-fix-go/constants"
+	"prime-fix-go/model"
+	"prime-fix-go/risk"
+	"prime-fix-go/utils"
 	"strings"
+	"sync/atomic"
 )
 
-func (a *FixApp) handleNew(parts []string) {
+func (a *FixApp) handleNew(parts []string) (pendingAck, bool) {
 	if len(parts) < 6 {
-		fmt.Println("error: insufficient arguments")
-		fmt.Println("usage: new <symbol> <MARKET|LIMIT|VWAP> <BUY|SELL> <BASE|QUOTE> <qty> [price] [start_time] [participation_rate] [expire_time]")
-		return
+		fmt.Fprintln(a.out, "error: insufficient arguments")
+		fmt.Fprintln(a.out, "usage: new <symbol> <MARKET|LIMIT|VWAP> <BUY|SELL> <BASE|QUOTE> <qty> [price] [start_time] [participation_rate] [expire_time]")
+		fmt.Fprintln(a.out, "       new <symbol> LIMIT <BUY|SELL> <BASE|QUOTE> <qty> <price> [tif=GTC|GTD|IOC|FOK] [expire=<expire_time>] [exec=POST_ONLY,...]")
+		fmt.Fprintln(a.out, "       new <symbol> STOP_LIMIT <BUY|SELL> <BASE|QUOTE> <qty> <limit_price> <stop_price>")
+		fmt.Fprintln(a.out, "       new <symbol> TWAP <BUY|SELL> <BASE|QUOTE> <qty> <start_time> <expire_time> [price]")
+		return pendingAck{}, false
 	}
 
-	symbol := parts[1]
-	ordType := strings.ToUpper(parts[2])
-	side := strings.ToUpper(parts[3])
-	qtyType := strings.ToUpper(parts[4])
-	qty := parts[5]
-	var price string
-
-	if side != "BUY" && side != "SELL" {
-		fmt.Println("error: side must be BUY or SELL")
-		return
+	req, err := parseNewOrder(parts[1:])
+	if err != nil {
+		a.printError(err)
+		return pendingAck{}, false
 	}
-
-	if qtyType != "BASE" && qtyType != "QUOTE" {
-		fmt.Println("error: quantity type must be BASE or QUOTE")
-		return
+	info, err := a.PlaceOrder(req)
+	if err != nil {
+		a.printError(err)
+		return pendingAck{}, false
 	}
+	a.printRounded("price", req.Price, info.LimitPrice)
+	a.printRounded("stop price", req.StopPx, info.StopPx)
+	fmt.Fprintf(a.out, "Sent %s %s order %s\n", info.OrdType, req.Side, info.ClOrdId)
+	return pendingAck{sentOrder, info.ClOrdId}, true
+}
 
-	if _, err := strconv.ParseFloat(qty, 64); err != nil {
-		fmt.Println("error: qty must be a valid number")
-		return
+// parseNewOrder reads the arguments of the new command: symbol, order type,
+// side, quantity type and quantity, then the arguments of the order type.
+func parseNewOrder(args []string) (builder.NewOrderRequest, error) {
+	if len(args) < 5 {
+		return builder.NewOrderRequest{}, fmt.Errorf("insufficient arguments: <symbol> <ordType> <BUY|SELL> <BASE|QUOTE> <qty> ...")
 	}
+	req := builder.NewOrderRequest{
+		Symbol:  args[0],
+		OrdType: strings.ToUpper(args[1]),
+		Side:    strings.ToUpper(args[2]),
+		QtyType: strings.ToUpper(args[3]),
+		Qty:     args[4],
+	}
+	args = args[5:]
 
-	switch ordType {
-	case "MARKET":
-		if len(parts) > 6 {
-			fmt.Println("error: MARKET orders should not include a price")
-			return
-		}
-	case "LIMIT":
-		if len(parts) < 7 {
-			fmt.Println("error: price must be specified for LIMIT orders")
-			return
-		}
-		price = parts[6]
-		if _, err := strconv.ParseFloat(price, 64); err != nil {
-			fmt.Println("error: price must be a valid number")
-			return
-		}
-	case "VWAP":
-		if len(parts) < 7 {
-			fmt.Println("error: price must be specified for VWAP orders")
-			return
+	switch req.OrdType {
+	case constants.OrdTypeMarket:
+		if len(args) > 0 {
+			return req, fmt.Errorf("MARKET orders should not include a price")
 		}
-		price = parts[6]
-		if _, err := strconv.ParseFloat(price, 64); err != nil {
-			fmt.Println("error: price must be a valid number")
-			return
+	case constants.OrdTypeLimit:
+		req.Price = utils.GetOptional(args, 0)
+		if len(args) > 1 {
+			options, err := parseOptions(args[1:], "tif", "expire", "exec")
+			if err != nil {
+				return req, err
+			}
+			if options["exec"] != "" {
+				if req.ExecInst, err = builder.ParseExecInst(options["exec"]); err != nil {
+					return req, err
+				}
+			}
+			req.TimeInForce = strings.ToUpper(options["tif"])
+			req.ExpireTime = options["expire"]
 		}
-	default:
-		fmt.Println("error: order type must be MARKET, LIMIT, or VWAP")
-		return
+	case constants.OrdTypeStopLimit:
+		req.Price = utils.GetOptional(args, 0)
+		req.StopPx = utils.GetOptional(args, 1)
+	case constants.OrdTypeVwap:
+		req.Price = utils.GetOptional(args, 0)
+		req.StartTime = utils.GetOptional(args, 1)
+		req.ParticipationRate = utils.GetOptional(args, 2)
+		req.ExpireTime = utils.GetOptional(args, 3)
+	case constants.OrdTypeTwap:
+		req.StartTime = utils.GetOptional(args, 0)
+		req.ExpireTime = utils.GetOptional(args, 1)
+		req.Price = utils.GetOptional(args, 2)
 	}
+	return req, nil
+}
 
-	var vwapParams []string
-	if ordType == "VWAP" && len(parts) > 7 {
-		vwapParams = parts[7:]
+// printError reports a failed request: one line per invalid field, or the
+// risk check that blocked it.
+func (a *FixApp) printError(err error) {
+	var invalid *builder.ValidationError
+	var violation *risk.Violation
+	switch {
+	case errors.As(err, &invalid):
+		for _, field := range invalid.Fields {
+			fmt.Fprintf(a.out, "error: %v\n", field)
+		}
+	case errors.As(err, &violation):
+		fmt.Fprintf(a.out, "blocked: %v\n", err)
+	default:
+		fmt.Fprintf(a.out, "error: %v\n", err)
 	}
+}
 
-	msg, err := builder.BuildNew(symbol, ordType, side, qtyType, qty, price, a.config.PortfolioId, a.config, vwapParams...)
-	if err != nil {
-		fmt.Printf("Error building order: %v\n", err)
-		return
-	}
-	err = quickfix.SendToTarget(msg, a.SessionId)
-	if err != nil {
-		return
+// parseOptions reads key=value arguments, accepting only the given keys.
+func parseOptions(args []string, keys ...string) (map[string]string, error) {
+	options := make(map[string]string, len(args))
+	for _, arg := range args {
+		key, value, found := strings.Cut(arg, "=")
+		key = strings.ToLower(key)
+		if !found || value == "" {
+			return nil, fmt.Errorf("expected key=value, got %q", arg)
+		}
+		known := false
+		for _, k := range keys {
+			known = known || k == key
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown option %q (%s)", key, strings.Join(keys, ", "))
+		}
+		options[key] = value
 	}
+	return options, nil
 }
 
-func (a *FixApp) handleStatus(parts []string) {
+func (a *FixApp) handleStatus(parts []string) bool {
 	if len(parts) < 2 {
-		fmt.Println("usage: status <ClOrdId> [OrderId] [Side] [Symbol]")
-		return
+		fmt.Fprintln(a.out, "usage: status <ClOrdId> [OrderId] [Side] [Symbol]")
+		return false
 	}
 	cl := parts[1]
 	var ord, side, sym string
@@ -116,7 +160,8 @@ func (a *FixApp) handleStatus(parts []string) {
 	if len(parts) > 4 {
 		sym = parts[4]
 	}
-	if cached, ok := a.orders[cl]; ok {
+	if cached, ok := a.Order(cl); ok {
+		PrintOrder(a.out, cached)
 		if ord == "" {
 			ord = cached.OrderId
 		}
@@ -128,77 +173,154 @@ func (a *FixApp) handleStatus(parts []string) {
 		}
 	}
 	if ord == "" || side == "" || sym == "" {
-		fmt.Println("need OrderId, Side, and Symbol (not cached)")
-		return
+		fmt.Fprintln(a.out, "need OrderId, Side, and Symbol (not cached)")
+		return false
 	}
-	_ = quickfix.SendToTarget(builder.BuildStatus(cl, ord, side, sym, a.config), a.SessionId)
+	if err := a.send(builder.BuildStatus(cl, ord, side, sym, a.config)); err != nil {
+		a.printError(err)
+		return false
+	}
+	return true
 }
 
-func (a *FixApp) handleCancel(parts []string) {
+func (a *FixApp) handleCancel(parts []string) (pendingAck, bool) {
 	if len(parts) < 2 {
-		fmt.Println("usage: cancel <ClOrdId>")
-		return
+		fmt.Fprintln(a.out, "usage: cancel <ClOrdId>")
+		return pendingAck{}, false
 	}
-	info, ok := a.orders[parts[1]]
-	if !ok {
-		fmt.Println("unknown ClOrdId (not in cache)")
-		return
+	if _, err := a.CancelOrder(parts[1]); err != nil {
+		a.printError(err)
+		return pendingAck{}, false
 	}
-	_ = quickfix.SendToTarget(builder.BuildCancel(info, a.config.PortfolioId, a.config), a.SessionId)
+	return pendingAck{sentCancel, parts[1]}, true
 }
 
-func (a *FixApp) handleList() {
-	if len(a.orders) == 0 {
-		fmt.Println("(no cached orders)")
-		return
+func (a *FixApp) handleReplace(parts []string) (pendingAck, bool) {
+	if len(parts) < 3 {
+		fmt.Fprintln(a.out, "usage: replace <ClOrdId> [price=<price>] [stop=<stop_price>] [qty=<qty>] [expire=<expire_time>]")
+		return pendingAck{}, false
 	}
-	for _, o := range a.orders {
-		fmt.Printf("%-20s → %s (%s %s %s)\n",
-			o.ClOrdId, o.OrderId, o.Side, o.Symbol, o.Quantity)
+	options, err := parseOptions(parts[2:], "price", "stop", "qty", "expire")
+	if err != nil {
+		fmt.Fprintf(a.out, "error: %v\n", err)
+		return pendingAck{}, false
 	}
+	amended, err := a.ReplaceOrder(parts[1], Amendment{
+		Price:      options["price"],
+		StopPx:     options["stop"],
+		Qty:        options["qty"],
+		ExpireTime: options["expire"],
+	})
+	if err != nil {
+		a.printError(err)
+		return pendingAck{}, false
+	}
+	a.printRounded("price", options["price"], amended.LimitPrice)
+	a.printRounded("stop price", options["stop"], amended.StopPx)
+	fmt.Fprintf(a.out, "Sent replace %s for %s\n", amended.ClOrdId, amended.OrigClOrdId)
+	return pendingAck{sentReplace, amended.ClOrdId}, true
 }
 
-func (a *FixApp) handleRfq(parts []string) {
-	if len(parts) < 6 {
-		fmt.Println("error: insufficient arguments")
-		fmt.Println("usage: rfq <symbol> <BUY|SELL> <BASE|QUOTE> <qty> <price>")
+func (a *FixApp) handleList() {
+	orders := a.Orders()
+	if len(orders) == 0 {
+		fmt.Fprintln(a.out, "(no cached orders)")
 		return
 	}
-
-	symbol := parts[1]
-	side := strings.ToUpper(parts[2])
-	qtyType := strings.ToUpper(parts[3])
-	qty := parts[4]
-	price := parts[5]
-
-	if side != "BUY" && side != "SELL" {
-		fmt.Println("error: side must be BUY or SELL")
-		return
+	for _, o := range orders {
+		fmt.Fprintf(a.out, "%-20s → %s (%s %s %s) %-16s filled %s @ %s",
+			o.ClOrdId, o.OrderId, o.Side, o.Symbol, o.Quantity,
+			orDash(o.Status), orDash(o.CumQty), orDash(o.AvgPx))
+		if o.StopPx != "" {
+			fmt.Fprintf(a.out, "  stop %s limit %s", o.StopPx, orDash(o.LimitPrice))
+		}
+		if o.Outcome != "" {
+			fmt.Fprintf(a.out, "  %s", describeOutcome(o))
+		}
+		fmt.Fprintln(a.out)
 	}
+}
 
-	if qtyType != "BASE" && qtyType != "QUOTE" {
-		fmt.Println("error: quantity type must be BASE or QUOTE")
-		return
+// PrintOrder writes the cached state of an order to w on one line.
+func PrintOrder(w io.Writer, o model.OrderInfo) {
+	fmt.Fprintf(w, "%s: %s %s %s qty %s, status %s, filled %s, leaves %s, avg px %s",
+		o.ClOrdId, orDash(o.OrdType), o.Side, o.Symbol, o.Quantity,
+		orDash(o.Status), orDash(o.CumQty), orDash(o.LeavesQty), orDash(o.AvgPx))
+	if o.NetAvgPrice != "" {
+		fmt.Fprintf(w, ", net avg px %s", o.NetAvgPrice)
+	}
+	if o.StopPx != "" {
+		fmt.Fprintf(w, ", stop %s limit %s", o.StopPx, orDash(o.LimitPrice))
+	}
+	if o.TimeInForce != "" {
+		fmt.Fprintf(w, ", tif %s", o.TimeInForce)
+	}
+	if o.ExecInst != "" {
+		fmt.Fprintf(w, ", exec inst %s", o.ExecInst)
+	}
+	if o.Outcome != "" {
+		fmt.Fprintf(w, ", %s", describeOutcome(o))
 	}
+	if o.StartTime != "" {
+		fmt.Fprintf(w, ", starts %s", o.StartTime)
+	}
+	if o.ExpireTime != "" {
+		fmt.Fprintf(w, ", expires %s", o.ExpireTime)
+	}
+	if o.OrigClOrdId != "" {
+		fmt.Fprintf(w, ", replaces %s", o.OrigClOrdId)
+	}
+	if o.ReplacedBy != "" {
+		fmt.Fprintf(w, ", replaced by %s", o.ReplacedBy)
+	}
+	if o.QuoteId != "" {
+		fmt.Fprintf(w, ", accepts quote %s for RFQ %s", o.QuoteId, orDash(o.QuoteReqId))
+	}
+	if o.IllegalTransition != "" {
+		fmt.Fprintf(w, " [%s]", o.IllegalTransition)
+	}
+	fmt.Fprintln(w)
+}
 
-	if _, err := strconv.ParseFloat(qty, 64); err != nil {
-		fmt.Println("error: qty must be a valid number")
-		return
+func orDash(s string) string {
+	if s == "" {
+		return "-"
 	}
+	return s
+}
 
-	if _, err := strconv.ParseFloat(price, 64); err != nil {
-		fmt.Println("error: price must be a valid number")
-		return
+func (a *FixApp) handleRfq(parts []string) (pendingAck, bool) {
+	if len(parts) < 6 {
+		fmt.Fprintln(a.out, "error: insufficient arguments")
+		fmt.Fprintln(a.out, "usage: rfq <symbol> <BUY|SELL> <BASE|QUOTE> <qty> <price>")
+		return pendingAck{}, false
 	}
 
-	msg, err := builder.BuildQuoteRequest(symbol, side, qtyType, qty, price, a.config.PortfolioId, a.config)
+	rfq, err := a.SendRfq(builder.QuoteRequest{
+		Symbol:  parts[1],
+		Side:    parts[2],
+		QtyType: parts[3],
+		Qty:     parts[4],
+		Price:   parts[5],
+	})
 	if err != nil {
-		fmt.Printf("Error building RFQ: %v\n", err)
-		return
+		a.printError(err)
+		return pendingAck{}, false
 	}
-	err = quickfix.SendToTarget(msg, a.SessionId)
-	if err != nil {
+	a.printRounded("price", parts[5], rfq.Price)
+	fmt.Fprintf(a.out, "Sent RFQ %s for %s %s %s %s @ %s\n", rfq.QuoteReqId, rfq.Side, rfq.QtyType, rfq.OrderQty, rfq.Symbol, rfq.Price)
+	return pendingAck{sentRfq, rfq.QuoteReqId}, true
+}
+
+// replRunning is set while Repl owns the terminal.
+var replRunning atomic.Bool
+
+// notify prints an asynchronous event on its own line and, when the REPL is
+// running, redraws the prompt.
+func (a *FixApp) notify(line string) {
+	if !replRunning.Load() {
+		fmt.Fprintln(a.out, line)
 		return
 	}
-	fmt.Printf("Sent RFQ for %s %s %s %s @ %s\n", side, qtyType, qty, symbol, price)
+	fmt.Fprintf(a.out, "\r%s\nFIX> ", line)
 }
@@ -0,0 +1,188 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fixclient
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"prime-fix-go/constants"
+	"prime-fix-go/events"
+	"prime-fix-go/model"
+	"prime-fix-go/utils"
+
+	"github.com/quickfixgo/quickfix"
+)
+
+// trackRfq records a Quote Request the client has just sent as SENT.
+func (a *FixApp) trackRfq(rfq model.QuoteRequestInfo) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := time.Now().UTC().Format(time.RFC3339)
+	rfq.Status = model.RfqStatusSent
+	rfq.CreatedAt = now
+	rfq.UpdatedAt = now
+	a.putRfqLocked(rfq)
+}
+
+// updateRfqLocked moves an RFQ to status and applies update to it, leaving it
+// unchanged if the lifecycle does not allow the move. The caller must hold a.mu.
+func (a *FixApp) updateRfqLocked(quoteReqId, status string, update func(*model.QuoteRequestInfo)) (model.QuoteRequestInfo, bool) {
+	rfq, ok := a.rfqs[quoteReqId]
+	if !ok {
+		return rfq, false
+	}
+	if err := rfq.Transition(status); err != nil {
+		log.Printf("⚠ %s: %v", quoteReqId, err)
+		return rfq, false
+	}
+	if update != nil {
+		update(&rfq)
+	}
+	rfq.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	a.putRfqLocked(rfq)
+	return rfq, true
+}
+
+// putRfqLocked updates the cached RFQ and persists it. The caller must hold a.mu.
+func (a *FixApp) putRfqLocked(rfq model.QuoteRequestInfo) {
+	a.rfqs[rfq.QuoteReqId] = rfq
+	if err := a.store.SaveRfq(rfq); err != nil {
+		log.Println("rfq save err:", err)
+	}
+}
+
+// loadRfqs restores persisted RFQs, expiring any whose quote ran out while the
+// client was not running. The caller must hold a.mu.
+func (a *FixApp) loadRfqs() error {
+	rfqs, err := a.store.LoadRfqs()
+	if err != nil {
+		return err
+	}
+	a.rfqs = rfqs
+	for id, rfq := range rfqs {
+		if rfq.Status != model.RfqStatusQuoted {
+			continue
+		}
+		if expiry, ok := parseFixTime(rfq.ValidUntilTime); ok && time.Now().After(expiry) {
+			a.updateRfqLocked(id, model.RfqStatusExpired, nil)
+		}
+	}
+	return nil
+}
+
+// linkRfqOrderLocked tags an accept order with the quote and RFQ it executes,
+// for reports that arrive before the order was cached. The caller must hold a.mu.
+func (a *FixApp) linkRfqOrderLocked(info *model.OrderInfo) {
+	if info.QuoteReqId != "" || !strings.HasPrefix(info.ClOrdId, "rfq-") {
+		return
+	}
+	for _, rfq := range a.rfqs {
+		if rfq.ClOrdId == info.ClOrdId {
+			info.QuoteReqId = rfq.QuoteReqId
+			info.QuoteId = rfq.QuoteId
+			return
+		}
+	}
+}
+
+// syncRfqLocked carries the state of an accept order over to its RFQ. The
+// caller must hold a.mu.
+func (a *FixApp) syncRfqLocked(order model.OrderInfo) {
+	rfq, ok := a.rfqs[order.QuoteReqId]
+	if !ok || rfq.ClOrdId != order.ClOrdId {
+		return
+	}
+	var status string
+	switch order.Status {
+	case model.OrderStatusFilled:
+		status = model.RfqStatusFilled
+	case model.OrderStatusRejected:
+		status = model.RfqStatusRejected
+	case model.OrderStatusCanceled, model.OrderStatusExpired:
+		status = model.RfqStatusExpired
+	}
+	if status == "" || status == rfq.Status {
+		if order.OrderId != "" && rfq.OrderId != order.OrderId {
+			rfq.OrderId = order.OrderId
+			rfq.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+			a.putRfqLocked(rfq)
+		}
+		return
+	}
+	updated, ok := a.updateRfqLocked(rfq.QuoteReqId, status, func(r *model.QuoteRequestInfo) {
+		if order.OrderId != "" {
+			r.OrderId = order.OrderId
+		}
+		if status == model.RfqStatusRejected {
+			r.Text = order.Text
+		}
+	})
+	if ok {
+		log.Printf("⇡ RFQ %s %s → %s", updated.QuoteReqId, rfq.Status, updated.Status)
+	}
+}
+
+func (a *FixApp) handleQuoteAck(msg *quickfix.Message) {
+	quoteReqId := utils.GetString(msg, constants.TagQuoteReqId)
+	quoteAckStatus := utils.GetString(msg, constants.TagQuoteAckStatus)
+	rejectReason := utils.GetString(msg, constants.TagQuoteRejectReason)
+	text := utils.GetString(msg, constants.TagText)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	ack := events.QuoteAck{QuoteReqId: quoteReqId, Status: "acknowledged", RejectReason: rejectReason, Text: text}
+	if quoteAckStatus == constants.QuoteAckStatusRejected {
+		ack.Status = "rejected"
+	}
+	a.events.Publish(events.TypeQuoteAck, a.rfqs[quoteReqId].Symbol, ack)
+	if quoteAckStatus == constants.QuoteAckStatusRejected {
+		log.Printf("✗ quote request %s rejected: reason=%s, text=%s", quoteReqId, rejectReason, text)
+		a.updateRfqLocked(quoteReqId, model.RfqStatusRejected, func(r *model.QuoteRequestInfo) {
+			r.Text = text
+		})
+		return
+	}
+	log.Printf("? quote acknowledgment for %s: status=%s", quoteReqId, quoteAckStatus)
+	if rfq, ok := a.rfqs[quoteReqId]; ok && rfq.Status == model.RfqStatusSent {
+		a.updateRfqLocked(quoteReqId, model.RfqStatusAcked, nil)
+	}
+}
+
+func (a *FixApp) handleRfqs() {
+	rfqs := a.Rfqs()
+	if len(rfqs) == 0 {
+		fmt.Fprintln(a.out, "(no RFQs)")
+		return
+	}
+	for _, r := range rfqs {
+		fmt.Fprintf(a.out, "%-24s %s %s %s %s @ %s  %-8s",
+			r.QuoteReqId, r.Side, r.Symbol, r.QtyType, r.OrderQty, r.Price, r.Status)
+		if r.QuoteId != "" {
+			fmt.Fprintf(a.out, " quote %s %s @ %s", r.QuoteId, orDash(r.QuoteQty), orDash(r.QuotePx))
+		}
+		if r.ClOrdId != "" {
+			fmt.Fprintf(a.out, " order %s", r.ClOrdId)
+		}
+		if r.Text != "" {
+			fmt.Fprintf(a.out, " (%s)", r.Text)
+		}
+		fmt.Fprintln(a.out)
+	}
+}
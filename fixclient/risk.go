@@ -0,0 +1,94 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fixclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"prime-fix-go/amount"
+	"prime-fix-go/model"
+	"prime-fix-go/positions"
+	"prime-fix-go/risk"
+)
+
+// SetRiskEngine installs the pre-trade checks every outbound order must pass.
+func (a *FixApp) SetRiskEngine(engine *risk.Engine) {
+	a.risk = engine
+}
+
+// checkRisk runs the pre-trade checks for an order about to be sent. A
+// replace does not add an open order, so replacing excludes the order being
+// amended from the open-order count.
+func (a *FixApp) checkRisk(symbol, qtyType, qty, price string, replacing bool) error {
+	if a.risk == nil {
+		return nil
+	}
+	order := risk.Order{Symbol: symbol, QtyType: qtyType}
+	if d, err := amount.Parse(qty); err == nil {
+		order.Qty = d
+	}
+	if d, err := amount.Parse(price); err == nil {
+		order.Price = d
+	}
+
+	state := risk.State{}
+	if mark, ok := a.positions.MarkFor(symbol); ok && mark.Source == positions.MarkSourceQuote {
+		state.RefPrice = mark.Price
+	}
+
+	a.mu.RLock()
+	for _, o := range a.orders {
+		if o.Status != "" && !model.IsTerminalStatus(o.Status) {
+			state.OpenOrders++
+		}
+	}
+	today := time.Now().UTC().Format("20060102")
+	for _, f := range a.fills {
+		if len(f.TransactTime) < 8 || f.TransactTime[:8] != today {
+			continue
+		}
+		px, pxErr := amount.Parse(f.LastPx)
+		shares, sharesErr := amount.Parse(f.LastShares)
+		if pxErr == nil && sharesErr == nil {
+			state.DailyNotional = state.DailyNotional.Add(px.Mul(shares))
+		}
+	}
+	a.mu.RUnlock()
+
+	if replacing && state.OpenOrders > 0 {
+		state.OpenOrders--
+	}
+	return a.risk.Check(order, state)
+}
+
+func (a *FixApp) handleRisk(parts []string) {
+	if a.risk == nil {
+		fmt.Fprintln(a.out, "(no risk limits configured; set RiskLimitsFile in fix.cfg)")
+		return
+	}
+	if len(parts) > 1 && parts[1] == "reload" {
+		if err := a.risk.Reload(); err != nil {
+			fmt.Fprintf(a.out, "error reloading risk limits: %v\n", err)
+			return
+		}
+		fmt.Fprintf(a.out, "reloaded risk limits from %s\n", a.risk.Path())
+	}
+	data, _ := json.MarshalIndent(a.risk.Limits(), "", "  ")
+	fmt.Fprintln(a.out, string(data))
+}
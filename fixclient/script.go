@@ -0,0 +1,299 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fixclient
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"prime-fix-go/events"
+	"prime-fix-go/model"
+)
+
+// DefaultAckTimeout bounds how long a script waits for an order or RFQ to be
+// acknowledged.
+const DefaultAckTimeout = 30 * time.Second
+
+// What a command sent and must hear back about before a script moves on.
+const (
+	sentOrder   = "order"
+	sentCancel  = "cancel"
+	sentReplace = "replace"
+	sentRfq     = "rfq"
+)
+
+// sendsMessage lists the commands that need a logged-on session.
+var sendsMessage = map[string]bool{
+	"new": true, "status": true, "cancel": true, "replace": true, "rfq": true, "accept": true,
+}
+
+// pendingAck names the order or RFQ a command sent; the zero value means nothing
+// needs acknowledging.
+type pendingAck struct {
+	kind string
+	id   string
+}
+
+// ScriptResult summarizes a script run.
+type ScriptResult struct {
+	Commands int
+	Failures []string
+}
+
+// Failed reports whether any command failed.
+func (r ScriptResult) Failed() bool {
+	return len(r.Failures) > 0
+}
+
+func (r ScriptResult) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d commands, %d failed", r.Commands, len(r.Failures))
+	for _, f := range r.Failures {
+		b.WriteString("\n  " + f)
+	}
+	return b.String()
+}
+
+// RunScript executes REPL commands read from in, one per line, and waits up
+// to ackTimeout for each order, cancel, replace or RFQ to be acknowledged
+// before the next line. Blank lines and lines starting with # are skipped,
+// and two directives are understood:
+//
+//	sleep <duration>                      pause, e.g. sleep 2s
+//	wait [<id> <STATUS>[,<STATUS>...]] [timeout]
+//	                                      wait for logon, or for an order or RFQ to reach a status
+//
+// $last stands for the ClOrdID or QuoteReqID the most recent command sent and
+// $quote for the QuoteID answering the most recent RFQ. A failed command does
+// not stop the script; exit does.
+func RunScript(app *FixApp, in io.Reader, ackTimeout time.Duration) ScriptResult {
+	if ackTimeout <= 0 {
+		ackTimeout = DefaultAckTimeout
+	}
+	s := &script{app: app, timeout: ackTimeout}
+	scanner := bufio.NewScanner(in)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fmt.Fprintf(app.out, "[%d] %s\n", lineNo, line)
+		s.result.Commands++
+		exit, err := s.run(s.expand(strings.Fields(line)))
+		if err != nil {
+			fmt.Fprintf(app.out, "✗ line %d: %v\n", lineNo, err)
+			s.result.Failures = append(s.result.Failures, fmt.Sprintf("line %d: %s: %v", lineNo, line, err))
+		}
+		if exit {
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		s.result.Failures = append(s.result.Failures, fmt.Sprintf("reading script: %v", err))
+	}
+	return s.result
+}
+
+type script struct {
+	app     *FixApp
+	timeout time.Duration
+	last    string
+	result  ScriptResult
+}
+
+// expand replaces $last and $quote in a command's arguments.
+func (s *script) expand(parts []string) []string {
+	for i, part := range parts {
+		switch part {
+		case "$last":
+			parts[i] = s.last
+		case "$quote":
+			if rfq, ok := s.app.rfq(s.last); ok {
+				parts[i] = rfq.QuoteId
+			}
+		}
+	}
+	return parts
+}
+
+func (s *script) run(parts []string) (exit bool, err error) {
+	switch strings.ToLower(parts[0]) {
+	case "sleep":
+		if len(parts) != 2 {
+			return false, fmt.Errorf("usage: sleep <duration>")
+		}
+		d, err := time.ParseDuration(parts[1])
+		if err != nil {
+			return false, fmt.Errorf("invalid duration %q", parts[1])
+		}
+		time.Sleep(d)
+		return false, nil
+	case "wait":
+		return false, s.wait(parts[1:])
+	}
+
+	if sendsMessage[strings.ToLower(parts[0])] {
+		if err := s.waitLogon(s.timeout); err != nil {
+			return false, err
+		}
+	}
+	sent, ok, exit := s.app.dispatch(parts)
+	if !ok {
+		return exit, fmt.Errorf("command failed")
+	}
+	if sent.id == "" {
+		return exit, nil
+	}
+	s.last = sent.id
+	return exit, s.awaitAck(sent)
+}
+
+// wait implements the wait directive.
+func (s *script) wait(args []string) error {
+	timeout := s.timeout
+	if n := len(args); n == 1 || n == 3 {
+		d, err := time.ParseDuration(args[n-1])
+		if err != nil {
+			return fmt.Errorf("invalid timeout %q", args[n-1])
+		}
+		timeout, args = d, args[:n-1]
+	}
+	switch len(args) {
+	case 0:
+		return s.waitLogon(timeout)
+	case 2:
+	default:
+		return fmt.Errorf("usage: wait [<id> <STATUS>[,<STATUS>...]] [timeout]")
+	}
+
+	id := args[0]
+	statuses := strings.Split(strings.ToUpper(args[1]), ",")
+	var current string
+	err := s.app.waitFor(timeout, func() bool {
+		current = s.app.status(id)
+		for _, status := range statuses {
+			if current == status {
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return fmt.Errorf("%s is %s, not %s: %w", id, orDash(current), strings.Join(statuses, " or "), err)
+	}
+	return nil
+}
+
+func (s *script) waitLogon(timeout time.Duration) error {
+	if err := s.app.waitFor(timeout, s.app.LoggedOn); err != nil {
+		return fmt.Errorf("not logged on: %w", err)
+	}
+	return nil
+}
+
+// awaitAck waits until what a command sent is acknowledged and reports a
+// reject, a refused cancel or a timeout as an error.
+func (s *script) awaitAck(sent pendingAck) error {
+	status, err := s.app.awaitAck(sent, s.timeout)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(s.app.out, "✓ %s %s %s\n", sent.kind, sent.id, status)
+	return nil
+}
+
+// awaitAck waits up to timeout for the order or RFQ a command sent to leave
+// its pending status and returns the status it reached. A reject or a refused
+// cancel is reported as an error wrapping ErrRejected.
+func (a *FixApp) awaitAck(sent pendingAck, timeout time.Duration) (string, error) {
+	pending := map[string]string{
+		sentOrder:   model.OrderStatusPendingNew,
+		sentCancel:  model.OrderStatusPendingCancel,
+		sentReplace: model.OrderStatusPendingReplace,
+		sentRfq:     model.RfqStatusSent,
+	}[sent.kind]
+
+	var status string
+	err := a.waitFor(timeout, func() bool {
+		status = a.status(sent.id)
+		return status != "" && status != pending
+	})
+	switch {
+	case err != nil:
+		return status, fmt.Errorf("no acknowledgment for %s %s: %w", sent.kind, sent.id, err)
+	case status == model.OrderStatusRejected:
+		return status, fmt.Errorf("%s %s %w: %s", sent.kind, sent.id, ErrRejected, orDash(a.statusText(sent.id)))
+	case sent.kind == sentCancel && status != model.OrderStatusCanceled:
+		return status, fmt.Errorf("cancel of %s %w, order is %s", sent.id, ErrRejected, status)
+	}
+	return status, nil
+}
+
+// waitFor returns once done reports true, checking it again after every
+// event, or an error after timeout.
+func (a *FixApp) waitFor(timeout time.Duration, done func() bool) error {
+	sub := a.events.Subscribe(events.Filter{}, 0)
+	defer sub.Close()
+	updates := sub.Events
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	poll := time.NewTicker(250 * time.Millisecond)
+	defer poll.Stop()
+	for !done() {
+		select {
+		case _, ok := <-updates:
+			if !ok {
+				updates = nil
+			}
+		case <-poll.C:
+		case <-deadline.C:
+			return fmt.Errorf("%w after %s", ErrTimeout, timeout)
+		}
+	}
+	return nil
+}
+
+// status returns the status of the cached order or RFQ with the given id.
+func (a *FixApp) status(id string) string {
+	if info, ok := a.Order(id); ok {
+		return info.Status
+	}
+	if rfq, ok := a.rfq(id); ok {
+		return rfq.Status
+	}
+	return ""
+}
+
+// statusText returns the reject text of the cached order or RFQ with the
+// given id.
+func (a *FixApp) statusText(id string) string {
+	if info, ok := a.Order(id); ok {
+		return info.Text
+	}
+	rfq, _ := a.rfq(id)
+	return rfq.Text
+}
+
+func (a *FixApp) rfq(quoteReqId string) (model.QuoteRequestInfo, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	rfq, ok := a.rfqs[quoteReqId]
+	return rfq, ok
+}
@@ -0,0 +1,148 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fixclient
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"prime-fix-go/constants"
+	"prime-fix-go/events"
+	"prime-fix-go/model"
+
+	"github.com/quickfixgo/quickfix"
+)
+
+// answerOrders plays Prime for the rest of the test, answering every order
+// that reaches PENDING_NEW with an ExecutionReport of ordStatus.
+func answerOrders(t *testing.T, app *FixApp, ordStatus string) {
+	sub := app.Events().Subscribe(events.Filter{Types: []string{events.TypeOrder}}, 0)
+	t.Cleanup(sub.Close)
+	go func() {
+		for e := range sub.Events {
+			if info := e.Data.(model.OrderInfo); info.Status == model.OrderStatusPendingNew {
+				deliver(app, execReport(info.ClOrdId, ordStatus, ordStatus, map[quickfix.Tag]string{constants.TagText: "answered"}))
+			}
+		}
+	}()
+}
+
+func TestAwaitAck(t *testing.T) {
+	tests := []struct {
+		name       string
+		answer     string
+		cancel     bool
+		wantStatus string
+		wantErr    error
+	}{
+		{"order acknowledged", constants.OrdStatusNew, false, model.OrderStatusNew, nil},
+		{"order rejected", constants.OrdStatusRejected, false, model.OrderStatusRejected, ErrRejected},
+		{"order unanswered", "", false, model.OrderStatusPendingNew, ErrTimeout},
+		{"cancel refused", constants.OrdStatusNew, true, model.OrderStatusNew, ErrRejected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApp(t)
+			if tt.answer != "" {
+				answerOrders(t, app, tt.answer)
+			}
+			sent := pendingAck{sentOrder, placeOrder(t, app)}
+			if tt.cancel {
+				if _, err := app.awaitAck(sent, time.Second); err != nil {
+					t.Fatalf("awaitAck returned error: %v", err)
+				}
+				if _, err := app.CancelOrder(sent.id); err != nil {
+					t.Fatalf("CancelOrder returned error: %v", err)
+				}
+				sent.kind = sentCancel
+				deliver(app, inbound(constants.MsgTypeCancelReject, map[quickfix.Tag]string{
+					constants.TagOrigClOrdId:      sent.id,
+					constants.TagCxlRejResponseTo: constants.CxlRejResponseToCancel,
+					constants.TagCxlRejReason:     "0",
+				}))
+			}
+
+			status, err := app.awaitAck(sent, 100*time.Millisecond)
+			if status != tt.wantStatus {
+				t.Errorf("Expected status %s, got %s", tt.wantStatus, status)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRunScript(t *testing.T) {
+	tests := []struct {
+		name         string
+		answer       string
+		script       string
+		wantCommands int
+		wantFailures []string
+	}{
+		{
+			name:         "acknowledged",
+			answer:       constants.OrdStatusNew,
+			script:       "# place and check\n\nnew BTC-USD LIMIT BUY BASE 1 50000\nwait $last NEW 1s\nstatus $last\n",
+			wantCommands: 3,
+		},
+		{
+			name:         "rejected",
+			answer:       constants.OrdStatusRejected,
+			script:       "new BTC-USD LIMIT BUY BASE 1 50000\nlist\n",
+			wantCommands: 2,
+			wantFailures: []string{"line 1: new BTC-USD LIMIT BUY BASE 1 50000: order"},
+		},
+		{
+			name:         "failed commands do not stop the script",
+			script:       "bogus\nsleep soon\nwait nosuch FILLED 10ms\nlist\n",
+			wantCommands: 4,
+			wantFailures: []string{"line 1: bogus", "line 2: sleep soon", "line 3: wait nosuch FILLED"},
+		},
+		{
+			name:         "exit stops the script",
+			script:       "exit\nbogus\n",
+			wantCommands: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApp(t)
+			app.loggedOn.Store(true)
+			if tt.answer != "" {
+				answerOrders(t, app, tt.answer)
+			}
+
+			result := RunScript(app, strings.NewReader(tt.script), time.Second)
+
+			if result.Commands != tt.wantCommands {
+				t.Errorf("Expected %d commands, got %d", tt.wantCommands, result.Commands)
+			}
+			if result.Failed() != (len(tt.wantFailures) > 0) || len(result.Failures) != len(tt.wantFailures) {
+				t.Fatalf("Expected failures %q, got %q", tt.wantFailures, result.Failures)
+			}
+			for i, want := range tt.wantFailures {
+				if !strings.HasPrefix(result.Failures[i], want) {
+					t.Errorf("Expected failure %d to start %q, got %q", i, want, result.Failures[i])
+				}
+			}
+		})
+	}
+}
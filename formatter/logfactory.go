@@ -18,28 +18,69 @@ package formatter
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/quickfixgo/quickfix"
 )
 
-type TableLogFactory struct{}
+// Log formats accepted by NewLogFactory.
+const (
+	LogFormatTable = "table"
+	LogFormatRaw   = "raw"
+	LogFormatJson  = "json"
+	LogFormatNone  = "none"
+)
+
+// LogFormats lists the formats NewLogFactory accepts.
+var LogFormats = []string{LogFormatTable, LogFormatRaw, LogFormatJson, LogFormatNone}
+
+// NewLogFactory returns a factory for session logs written to out in format:
+// table prints each message as a colored table, raw as one line of
+// |-separated fields, json as one JSON object per line, and none discards
+// the log.
+func NewLogFactory(format string, out io.Writer) (quickfix.LogFactory, error) {
+	switch strings.ToLower(format) {
+	case LogFormatTable, "":
+		return &TableLogFactory{out: out}, nil
+	case LogFormatRaw, LogFormatJson:
+		return &lineLogFactory{format: strings.ToLower(format), out: out}, nil
+	case LogFormatNone:
+		return quickfix.NewNullLogFactory(), nil
+	}
+	return nil, fmt.Errorf("unknown log format %q (%s)", format, strings.Join(LogFormats, ", "))
+}
+
+type TableLogFactory struct {
+	out io.Writer
+}
 
 func NewTableLogFactory() *TableLogFactory {
 	return &TableLogFactory{}
 }
 
 func (f *TableLogFactory) Create() (quickfix.Log, error) {
-	return &TableLog{}, nil
+	return &TableLog{out: f.out}, nil
 }
 
 func (f *TableLogFactory) CreateSessionLog(sessionID quickfix.SessionID) (quickfix.Log, error) {
-	return &TableLog{SessionID: sessionID}, nil
+	return &TableLog{SessionID: sessionID, out: f.out}, nil
 }
 
 type TableLog struct {
 	SessionID quickfix.SessionID
+	out       io.Writer
+}
+
+func (l *TableLog) writer() io.Writer {
+	if l.out == nil {
+		return os.Stdout
+	}
+	return l.out
 }
 
 func (l *TableLog) OnIncoming(msg []byte) {
@@ -47,9 +88,9 @@ func (l *TableLog) OnIncoming(msg []byte) {
 	buffer := bytes.NewBuffer(msg)
 	if err := quickfix.ParseMessage(message, buffer); err == nil {
 		formatted := FormatFixMessage(message, "INCOMING")
-		fmt.Print(formatted)
+		fmt.Fprint(l.writer(), formatted)
 	} else {
-		fmt.Printf("Error parsing incoming message: %s\nRaw: %s\n", err, string(msg))
+		fmt.Fprintf(l.writer(), "Error parsing incoming message: %s\nRaw: %s\n", err, string(msg))
 	}
 }
 
@@ -58,21 +99,121 @@ func (l *TableLog) OnOutgoing(msg []byte) {
 	buffer := bytes.NewBuffer(msg)
 	if err := quickfix.ParseMessage(message, buffer); err == nil {
 		formatted := FormatFixMessage(message, "OUTGOING")
-		fmt.Print(formatted)
+		fmt.Fprint(l.writer(), formatted)
 	} else {
-		fmt.Printf("Error parsing outgoing message: %s\nRaw: %s\n", err, string(msg))
+		fmt.Fprintf(l.writer(), "Error parsing outgoing message: %s\nRaw: %s\n", err, string(msg))
 	}
 }
 
 func (l *TableLog) OnEvent(msg string) {
 	if !strings.Contains(msg, "Sending") && !strings.Contains(msg, "Received") {
-		fmt.Printf("Event: %s\n", msg)
+		fmt.Fprintf(l.writer(), "Event: %s\n", msg)
 	}
 }
 
 func (l *TableLog) OnEventf(format string, args ...interface{}) {
-	msg := fmt.Sprintf(format, args...)
-	if !strings.Contains(msg, "Sending") && !strings.Contains(msg, "Received") {
-		fmt.Printf("Event: %s\n", msg)
+	l.OnEvent(fmt.Sprintf(format, args...))
+}
+
+type lineLogFactory struct {
+	format string
+	out    io.Writer
+}
+
+func (f *lineLogFactory) Create() (quickfix.Log, error) {
+	return &lineLog{format: f.format, out: f.out}, nil
+}
+
+func (f *lineLogFactory) CreateSessionLog(sessionID quickfix.SessionID) (quickfix.Log, error) {
+	return &lineLog{format: f.format, out: f.out, session: sessionID.String()}, nil
+}
+
+// lineLog writes one line per message or event, as raw FIX or as JSON.
+type lineLog struct {
+	format  string
+	out     io.Writer
+	session string
+}
+
+// logLine is one line of the json log format.
+type logLine struct {
+	Time      string     `json:"time"`
+	Session   string     `json:"session,omitempty"`
+	Direction string     `json:"direction,omitempty"`
+	MsgType   string     `json:"msgType,omitempty"`
+	Fields    []logField `json:"fields,omitempty"`
+	Raw       string     `json:"raw,omitempty"`
+	Event     string     `json:"event,omitempty"`
+}
+
+type logField struct {
+	Tag   string `json:"tag"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func (l *lineLog) writer() io.Writer {
+	if l.out == nil {
+		return os.Stdout
+	}
+	return l.out
+}
+
+func (l *lineLog) OnIncoming(msg []byte) {
+	l.message("INCOMING", msg)
+}
+
+func (l *lineLog) OnOutgoing(msg []byte) {
+	l.message("OUTGOING", msg)
+}
+
+func (l *lineLog) message(direction string, msg []byte) {
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+	raw := string(bytes.ReplaceAll(msg, []byte{1}, []byte{'|'}))
+	if l.format == LogFormatRaw {
+		arrow := "<-"
+		if direction == "OUTGOING" {
+			arrow = "->"
+		}
+		fmt.Fprintf(l.writer(), "%s %s %s\n", now, arrow, raw)
+		return
 	}
+
+	line := logLine{Time: now, Session: l.session, Direction: direction}
+	message := quickfix.NewMessage()
+	if err := quickfix.ParseMessage(message, bytes.NewBuffer(msg)); err != nil {
+		line.Raw = raw
+	} else {
+		line.MsgType = getValueDescription("35", getHeader(message, 35))
+		for _, f := range messageFields(message) {
+			line.Fields = append(line.Fields, logField{Tag: f.Tag, Name: f.Name, Value: f.Value})
+		}
+	}
+	l.writeJson(line)
+}
+
+func (l *lineLog) OnEvent(msg string) {
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+	if l.format == LogFormatRaw {
+		fmt.Fprintf(l.writer(), "%s -- %s\n", now, msg)
+		return
+	}
+	l.writeJson(logLine{Time: now, Session: l.session, Event: msg})
+}
+
+func (l *lineLog) OnEventf(format string, args ...interface{}) {
+	l.OnEvent(fmt.Sprintf(format, args...))
+}
+
+func (l *lineLog) writeJson(line logLine) {
+	b, err := json.Marshal(line)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(l.writer(), "%s\n", b)
+}
+
+func getHeader(msg *quickfix.Message, tag quickfix.Tag) string {
+	v, _ := msg.Header.GetString(tag)
+	return v
 }
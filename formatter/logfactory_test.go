@@ -0,0 +1,76 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/quickfixgo/quickfix"
+)
+
+const heartbeat = "8=FIX.4.2\x019=53\x0135=0\x0134=2\x0149=CLIENT\x0152=20250801-16:00:00.000\x0156=COIN\x0110=164\x01"
+
+func newLog(t *testing.T, format string) (quickfix.Log, *bytes.Buffer) {
+	t.Helper()
+	var out bytes.Buffer
+	factory, err := NewLogFactory(format, &out)
+	if err != nil {
+		t.Fatalf("NewLogFactory(%q) returned error: %v", format, err)
+	}
+	log, err := factory.CreateSessionLog(quickfix.SessionID{BeginString: "FIX.4.2", SenderCompID: "CLIENT", TargetCompID: "COIN"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return log, &out
+}
+
+func TestJsonLog(t *testing.T) {
+	log, out := newLog(t, LogFormatJson)
+	log.OnOutgoing([]byte(heartbeat))
+
+	var line logLine
+	if err := json.Unmarshal(out.Bytes(), &line); err != nil {
+		t.Fatalf("Expected one JSON line, got %q: %v", out.String(), err)
+	}
+	if line.Direction != "OUTGOING" || line.MsgType != "HEARTBEAT" || line.Session == "" {
+		t.Errorf("Unexpected log line %+v", line)
+	}
+	found := false
+	for _, f := range line.Fields {
+		found = found || (f.Tag == "49" && f.Name == "SenderCompId" && f.Value == "CLIENT")
+	}
+	if !found {
+		t.Errorf("Expected SenderCompId in %+v", line.Fields)
+	}
+}
+
+func TestRawLog(t *testing.T) {
+	log, out := newLog(t, LogFormatRaw)
+	log.OnIncoming([]byte(heartbeat))
+	if got := out.String(); !strings.Contains(got, "<- 8=FIX.4.2|9=53|35=0|") || strings.Count(got, "\n") != 1 {
+		t.Errorf("Expected one |-separated line, got %q", got)
+	}
+}
+
+func TestUnknownLogFormat(t *testing.T) {
+	if _, err := NewLogFactory("xml", nil); err == nil {
+		t.Error("Expected an error for an unknown log format")
+	}
+}
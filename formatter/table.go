@@ -33,11 +33,14 @@ type FieldInfo struct {
 
 var fixFieldDescriptions = map[string]string{
 	"1":    "PortfolioId",
+	"6":    "AvgPx",
 	"8":    "BeginString",
 	"9":    "BodyLength",
 	"10":   "CheckSum",
 	"11":   "ClOrdID",
+	"12":   "Commission",
 	"14":   "CumQty",
+	"17":   "ExecID",
 	"30":   "LastMkt",
 	"31":   "LastPx",
 	"32":   "LastShares",
@@ -62,6 +65,7 @@ var fixFieldDescriptions = map[string]string{
 	"79":   "PortfolioId",
 	"96":   "SecureData",
 	"98":   "EncryptMethod",
+	"99":   "StopPx",
 	"108":  "HeartBtInt",
 	"126":  "ExpireTime",
 	"141":  "ResetSeqNumFlag",
@@ -75,6 +79,7 @@ var fixFieldDescriptions = map[string]string{
 	"554":  "Password",
 	"847":  "TargetStrategy",
 	"849":  "ParticipationRate",
+	"851":  "LastLiquidityInd",
 	"8002": "FilledAmount",
 	"8006": "NetAvgPrice",
 	"9406": "DropCopyFlag",
@@ -128,54 +133,26 @@ const (
 )
 
 func FormatFixMessage(msg *quickfix.Message, direction string) string {
-	var fields []FieldInfo
-
-	// Process header fields
-	for _, tag := range msg.Header.Tags() {
-		if value, err := msg.Header.GetString(tag); err == nil {
-			tagStr := strconv.Itoa(int(tag))
-			name := getFieldName(tagStr)
-			desc := getValueDescription(tagStr, value)
-			fields = append(fields, FieldInfo{
-				Tag:         tagStr,
-				Name:        name,
-				Value:       value,
-				Description: desc,
-			})
-		}
-	}
-
-	// Process body fields
-	for _, tag := range msg.Body.Tags() {
-		if value, err := msg.Body.GetString(tag); err == nil {
-			tagStr := strconv.Itoa(int(tag))
-			name := getFieldName(tagStr)
-			desc := getValueDescription(tagStr, value)
-			fields = append(fields, FieldInfo{
-				Tag:         tagStr,
-				Name:        name,
-				Value:       value,
-				Description: desc,
-			})
-		}
-	}
+	return formatTable(messageFields(msg), direction)
+}
 
-	// Process trailer fields
-	for _, tag := range msg.Trailer.Tags() {
-		if value, err := msg.Trailer.GetString(tag); err == nil {
-			tagStr := strconv.Itoa(int(tag))
-			name := getFieldName(tagStr)
-			desc := getValueDescription(tagStr, value)
-			fields = append(fields, FieldInfo{
-				Tag:         tagStr,
-				Name:        name,
-				Value:       value,
-				Description: desc,
-			})
+// messageFields lists the header, body and trailer fields of msg in order.
+func messageFields(msg *quickfix.Message) []FieldInfo {
+	var fields []FieldInfo
+	for _, part := range []*quickfix.FieldMap{&msg.Header.FieldMap, &msg.Body.FieldMap, &msg.Trailer.FieldMap} {
+		for _, tag := range part.Tags() {
+			if value, err := part.GetString(tag); err == nil {
+				tagStr := strconv.Itoa(int(tag))
+				fields = append(fields, FieldInfo{
+					Tag:         tagStr,
+					Name:        getFieldName(tagStr),
+					Value:       value,
+					Description: getValueDescription(tagStr, value),
+				})
+			}
 		}
 	}
-
-	return formatTable(fields, direction)
+	return fields
 }
 
 func getFieldName(tag string) string {
@@ -0,0 +1,324 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package instruments holds product reference data: tick size, increments and
+// size limits per symbol, loaded from a local JSON or CSV file.
+package instruments
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/shopspring/decimal"
+
+	"prime-fix-go/amount"
+)
+
+const (
+	FieldSymbol = "symbol"
+	FieldQty    = "qty"
+	FieldPrice  = "price"
+	FieldStopPx = "stopPx"
+)
+
+// Instrument is the reference data for one symbol. A zero increment or size
+// is not enforced.
+type Instrument struct {
+	Symbol         string
+	BaseIncrement  decimal.Decimal
+	QuoteIncrement decimal.Decimal
+	PriceIncrement decimal.Decimal
+	BaseMinSize    decimal.Decimal
+	BaseMaxSize    decimal.Decimal
+	QuoteMinSize   decimal.Decimal
+	QuoteMaxSize   decimal.Decimal
+}
+
+// record is one product as written in the catalog file. The names follow the
+// Prime products API, so a saved response can be used as the catalog.
+type record struct {
+	Id             string `json:"id"`
+	BaseIncrement  string `json:"base_increment"`
+	QuoteIncrement string `json:"quote_increment"`
+	PriceIncrement string `json:"price_increment"`
+	BaseMinSize    string `json:"base_min_size"`
+	BaseMaxSize    string `json:"base_max_size"`
+	QuoteMinSize   string `json:"quote_min_size"`
+	QuoteMaxSize   string `json:"quote_max_size"`
+}
+
+// Order is the part of an order checked against the catalog. Price and
+// StopPx are zero when the order has none.
+type Order struct {
+	Symbol  string
+	Side    string
+	QtyType string
+	Qty     decimal.Decimal
+	Price   decimal.Decimal
+	StopPx  decimal.Decimal
+}
+
+// Violation explains why an order does not fit its instrument.
+type Violation struct {
+	Field  string
+	Reason string
+}
+
+func (v *Violation) Error() string {
+	return v.Field + ": " + v.Reason
+}
+
+// Catalog is the set of known instruments, keyed by upper-case symbol.
+type Catalog struct {
+	mu          sync.RWMutex
+	path        string
+	instruments map[string]Instrument
+}
+
+// Load reads the catalog at path: a JSON array of products, a JSON object
+// with a "products" array, or a CSV file with a header row.
+func Load(path string) (*Catalog, error) {
+	c := &Catalog{path: path}
+	if err := c.Reload(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Reload rereads the catalog file, keeping the current data on error.
+func (c *Catalog) Reload() error {
+	if c.path == "" {
+		return fmt.Errorf("instrument catalog was not loaded from a file")
+	}
+	f, err := os.Open(c.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var records []record
+	if strings.EqualFold(filepath.Ext(c.path), ".csv") {
+		records, err = readCsv(f)
+	} else {
+		records, err = readJson(f)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid instrument catalog %s: %w", c.path, err)
+	}
+
+	instruments := make(map[string]Instrument, len(records))
+	for i, r := range records {
+		inst, err := r.instrument()
+		if err != nil {
+			return fmt.Errorf("invalid instrument catalog %s: product %d: %w", c.path, i+1, err)
+		}
+		instruments[strings.ToUpper(inst.Symbol)] = inst
+	}
+	c.mu.Lock()
+	c.instruments = instruments
+	c.mu.Unlock()
+	return nil
+}
+
+func readJson(r io.Reader) ([]record, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var records []record
+	if err := json.Unmarshal(data, &records); err == nil {
+		return records, nil
+	}
+	var wrapped struct {
+		Products []record `json:"products"`
+	}
+	if err := json.Unmarshal(data, &wrapped); err != nil {
+		return nil, err
+	}
+	return wrapped.Products, nil
+}
+
+func readCsv(r io.Reader) ([]record, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	var records []record
+	for _, row := range rows[1:] {
+		var rec record
+		for i, name := range rows[0] {
+			if i >= len(row) {
+				break
+			}
+			value := strings.TrimSpace(row[i])
+			switch strings.TrimSpace(strings.ToLower(name)) {
+			case "id", "symbol":
+				rec.Id = value
+			case "base_increment":
+				rec.BaseIncrement = value
+			case "quote_increment":
+				rec.QuoteIncrement = value
+			case "price_increment":
+				rec.PriceIncrement = value
+			case "base_min_size":
+				rec.BaseMinSize = value
+			case "base_max_size":
+				rec.BaseMaxSize = value
+			case "quote_min_size":
+				rec.QuoteMinSize = value
+			case "quote_max_size":
+				rec.QuoteMaxSize = value
+			}
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+func (r record) instrument() (Instrument, error) {
+	if r.Id == "" {
+		return Instrument{}, fmt.Errorf("id is required")
+	}
+	inst := Instrument{Symbol: r.Id}
+	fields := []struct {
+		name  string
+		value string
+		dst   *decimal.Decimal
+	}{
+		{"base_increment", r.BaseIncrement, &inst.BaseIncrement},
+		{"quote_increment", r.QuoteIncrement, &inst.QuoteIncrement},
+		{"price_increment", r.PriceIncrement, &inst.PriceIncrement},
+		{"base_min_size", r.BaseMinSize, &inst.BaseMinSize},
+		{"base_max_size", r.BaseMaxSize, &inst.BaseMaxSize},
+		{"quote_min_size", r.QuoteMinSize, &inst.QuoteMinSize},
+		{"quote_max_size", r.QuoteMaxSize, &inst.QuoteMaxSize},
+	}
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+		d, err := amount.Parse(f.value)
+		if err != nil || d.IsNegative() {
+			return Instrument{}, fmt.Errorf("%s: %s %q is not a valid amount", r.Id, f.name, f.value)
+		}
+		*f.dst = d
+	}
+	return inst, nil
+}
+
+// Path returns the file the catalog was loaded from.
+func (c *Catalog) Path() string {
+	return c.path
+}
+
+// Len returns the number of instruments.
+func (c *Catalog) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.instruments)
+}
+
+// Lookup finds the instrument for symbol, ignoring case.
+func (c *Catalog) Lookup(symbol string) (Instrument, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	inst, ok := c.instruments[strings.ToUpper(symbol)]
+	return inst, ok
+}
+
+// Symbols returns every symbol starting with prefix, ignoring case, in order.
+func (c *Catalog) Symbols(prefix string) []string {
+	prefix = strings.ToUpper(prefix)
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var symbols []string
+	for key, inst := range c.instruments {
+		if strings.HasPrefix(key, prefix) {
+			symbols = append(symbols, inst.Symbol)
+		}
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// Check returns a *Violation for the first way the order does not fit its
+// instrument. Prices off the tick are rejected, or with round set moved to
+// the tick on the passive side: down for a BUY, up for a SELL. The returned
+// order carries the canonical symbol and the prices to send.
+func (c *Catalog) Check(o Order, round bool) (Order, error) {
+	inst, ok := c.Lookup(o.Symbol)
+	if !ok {
+		return o, &Violation{FieldSymbol, o.Symbol + " is not in the instrument catalog"}
+	}
+	o.Symbol = inst.Symbol
+
+	increment, min, max := inst.BaseIncrement, inst.BaseMinSize, inst.BaseMaxSize
+	if strings.EqualFold(o.QtyType, "QUOTE") {
+		increment, min, max = inst.QuoteIncrement, inst.QuoteMinSize, inst.QuoteMaxSize
+	}
+	if !onIncrement(o.Qty, increment) {
+		return o, &Violation{FieldQty, fmt.Sprintf("%s is not a multiple of the %s increment %s", amount.Format(o.Qty), inst.Symbol, amount.Format(increment))}
+	}
+	if min.IsPositive() && o.Qty.LessThan(min) {
+		return o, &Violation{FieldQty, fmt.Sprintf("%s is below the %s minimum %s", amount.Format(o.Qty), inst.Symbol, amount.Format(min))}
+	}
+	if max.IsPositive() && o.Qty.GreaterThan(max) {
+		return o, &Violation{FieldQty, fmt.Sprintf("%s is above the %s maximum %s", amount.Format(o.Qty), inst.Symbol, amount.Format(max))}
+	}
+
+	var err error
+	if o.Price, err = inst.tick(FieldPrice, o.Side, o.Price, round); err != nil {
+		return o, err
+	}
+	if o.StopPx, err = inst.tick(FieldStopPx, o.Side, o.StopPx, round); err != nil {
+		return o, err
+	}
+	return o, nil
+}
+
+// tick checks that price is on the instrument's tick, rounding it when round
+// is set. A zero price is not checked.
+func (i Instrument) tick(field, side string, price decimal.Decimal, round bool) (decimal.Decimal, error) {
+	if price.IsZero() || onIncrement(price, i.PriceIncrement) {
+		return price, nil
+	}
+	if !round {
+		return price, &Violation{field, fmt.Sprintf("%s is not on the %s tick %s", amount.Format(price), i.Symbol, amount.Format(i.PriceIncrement))}
+	}
+	ticks, _ := price.QuoRem(i.PriceIncrement, 0)
+	if strings.EqualFold(side, "SELL") {
+		ticks = ticks.Add(decimal.NewFromInt(1))
+	}
+	rounded := ticks.Mul(i.PriceIncrement)
+	if !rounded.IsPositive() {
+		return price, &Violation{field, fmt.Sprintf("%s rounds to zero on the %s tick %s", amount.Format(price), i.Symbol, amount.Format(i.PriceIncrement))}
+	}
+	return rounded, nil
+}
+
+func onIncrement(value, increment decimal.Decimal) bool {
+	return !increment.IsPositive() || value.Mod(increment).IsZero()
+}
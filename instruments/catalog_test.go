@@ -0,0 +1,150 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package instruments
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+const catalogJson = `{"products": [
+  {"id": "BTC-USD", "base_increment": "0.00000001", "quote_increment": "0.01", "price_increment": "0.01",
+   "base_min_size": "0.0001", "base_max_size": "100", "quote_min_size": "1", "quote_max_size": "1000000"},
+  {"id": "ETH-USD", "base_increment": "0.0001", "price_increment": "0.05"}
+]}`
+
+func writeCatalog(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func dec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func TestLoadJsonAndCsv(t *testing.T) {
+	fromJson, err := Load(writeCatalog(t, "products.json", catalogJson))
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	fromCsv, err := Load(writeCatalog(t, "products.csv",
+		"id,base_increment,price_increment,base_min_size\nBTC-USD,0.00000001,0.01,0.0001\nETH-USD,0.0001,0.05,\n"))
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	for _, c := range []*Catalog{fromJson, fromCsv} {
+		if c.Len() != 2 {
+			t.Errorf("Expected 2 instruments, got %d", c.Len())
+		}
+		inst, ok := c.Lookup("btc-usd")
+		if !ok || !inst.PriceIncrement.Equal(dec("0.01")) || !inst.BaseMinSize.Equal(dec("0.0001")) {
+			t.Errorf("Unexpected BTC-USD instrument %+v", inst)
+		}
+	}
+}
+
+func TestLoadRejectsBadAmounts(t *testing.T) {
+	if _, err := Load(writeCatalog(t, "products.json", `[{"id": "BTC-USD", "price_increment": "1e-2"}]`)); err == nil {
+		t.Error("Expected an exponent increment to be rejected")
+	}
+	if _, err := Load(writeCatalog(t, "products.json", `[{"price_increment": "0.01"}]`)); err == nil {
+		t.Error("Expected a product without an id to be rejected")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	c, err := Load(writeCatalog(t, "products.json", catalogJson))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		order Order
+		field string
+	}{
+		{"ok", Order{Symbol: "btc-usd", Side: "BUY", QtyType: "BASE", Qty: dec("0.5"), Price: dec("30000.01")}, ""},
+		{"unknown symbol", Order{Symbol: "DOGE-USD", Side: "BUY", QtyType: "BASE", Qty: dec("1")}, FieldSymbol},
+		{"base increment", Order{Symbol: "BTC-USD", Side: "BUY", QtyType: "BASE", Qty: dec("0.000000001")}, FieldQty},
+		{"quote increment", Order{Symbol: "BTC-USD", Side: "BUY", QtyType: "QUOTE", Qty: dec("10.005")}, FieldQty},
+		{"below minimum", Order{Symbol: "BTC-USD", Side: "BUY", QtyType: "BASE", Qty: dec("0.00001")}, FieldQty},
+		{"above maximum", Order{Symbol: "BTC-USD", Side: "SELL", QtyType: "QUOTE", Qty: dec("2000000")}, FieldQty},
+		{"off tick", Order{Symbol: "BTC-USD", Side: "BUY", QtyType: "BASE", Qty: dec("1"), Price: dec("30000.005")}, FieldPrice},
+		{"stop off tick", Order{Symbol: "ETH-USD", Side: "BUY", QtyType: "BASE", Qty: dec("1"), Price: dec("2000"), StopPx: dec("1999.99")}, FieldStopPx},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checked, err := c.Check(tt.order, false)
+			if tt.field == "" {
+				if err != nil || checked.Symbol != "BTC-USD" {
+					t.Fatalf("Expected the order to pass as BTC-USD, got %s %v", checked.Symbol, err)
+				}
+				return
+			}
+			var v *Violation
+			if !errors.As(err, &v) || v.Field != tt.field {
+				t.Errorf("Expected a %s violation, got %v", tt.field, err)
+			}
+		})
+	}
+}
+
+func TestCheckRoundsToPassiveTick(t *testing.T) {
+	c, err := Load(writeCatalog(t, "products.json", catalogJson))
+	if err != nil {
+		t.Fatal(err)
+	}
+	buy, err := c.Check(Order{Symbol: "ETH-USD", Side: "BUY", QtyType: "BASE", Qty: dec("1"), Price: dec("2000.07")}, true)
+	if err != nil || !buy.Price.Equal(dec("2000.05")) {
+		t.Errorf("Expected BUY price rounded down to 2000.05, got %s %v", buy.Price, err)
+	}
+	sell, err := c.Check(Order{Symbol: "ETH-USD", Side: "SELL", QtyType: "BASE", Qty: dec("1"), Price: dec("2000.07")}, true)
+	if err != nil || !sell.Price.Equal(dec("2000.1")) {
+		t.Errorf("Expected SELL price rounded up to 2000.1, got %s %v", sell.Price, err)
+	}
+	if _, err := c.Check(Order{Symbol: "ETH-USD", Side: "BUY", QtyType: "BASE", Qty: dec("1"), Price: dec("0.01")}, true); err == nil {
+		t.Error("Expected a price that rounds to zero to be rejected")
+	}
+}
+
+func TestReloadKeepsDataOnError(t *testing.T) {
+	path := writeCatalog(t, "products.json", catalogJson)
+	c, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Reload(); err == nil {
+		t.Error("Expected Reload to fail on invalid JSON")
+	}
+	if c.Len() != 2 {
+		t.Errorf("Expected the previous catalog to be kept, got %d instruments", c.Len())
+	}
+	if got := c.Symbols("e"); len(got) != 1 || got[0] != "ETH-USD" {
+		t.Errorf("Expected completion of e to ETH-USD, got %v", got)
+	}
+}
@@ -23,18 +23,66 @@ type OrderInfo struct {
 	Symbol            string `json:"symbol"`
 	Quantity          string `json:"quantity"`
 	LimitPrice        string `json:"limitPrice"`
+	StopPx            string `json:"stopPx,omitempty"`
+	TimeInForce       string `json:"timeInForce,omitempty"`
+	ExecInst          string `json:"execInst,omitempty"`
+	Outcome           string `json:"outcome,omitempty"`
 	StartTime         string `json:"startTime,omitempty"`
 	ExpireTime        string `json:"expireTime,omitempty"`
 	ParticipationRate string `json:"participationRate,omitempty"`
+	OrdType           string `json:"ordType,omitempty"`
+	QtyType           string `json:"qtyType,omitempty"`
+	Status            string `json:"status,omitempty"`
+	ExecType          string `json:"execType,omitempty"`
+	CumQty            string `json:"cumQty,omitempty"`
+	LeavesQty         string `json:"leavesQty,omitempty"`
+	AvgPx             string `json:"avgPx,omitempty"`
+	FilledAmount      string `json:"filledAmount,omitempty"`
+	NetAvgPrice       string `json:"netAvgPrice,omitempty"`
+	Text              string `json:"text,omitempty"`
+	IllegalTransition string `json:"illegalTransition,omitempty"`
+	OrigClOrdId       string `json:"origClOrdId,omitempty"`
+	ReplacedBy        string `json:"replacedBy,omitempty"`
+	PrevStatus        string `json:"prevStatus,omitempty"`
+	CxlRejReason      string `json:"cxlRejReason,omitempty"`
+	CxlRejResponseTo  string `json:"cxlRejResponseTo,omitempty"`
+	QuoteId           string `json:"quoteId,omitempty"`
+	QuoteReqId        string `json:"quoteReqId,omitempty"`
+	UpdatedAt         string `json:"updatedAt,omitempty"`
+}
+
+type Fill struct {
+	ExecId             string `json:"execId"`
+	ClOrdId            string `json:"clOrdId"`
+	OrderId            string `json:"orderId"`
+	Account            string `json:"account,omitempty"`
+	Side               string `json:"side"`
+	Symbol             string `json:"symbol"`
+	LastPx             string `json:"lastPx"`
+	LastShares         string `json:"lastShares"`
+	TransactTime       string `json:"transactTime"`
+	Commission         string `json:"commission,omitempty"`
+	LiquidityIndicator string `json:"liquidityIndicator,omitempty"`
 }
 
 type QuoteRequestInfo struct {
-	QuoteReqId string `json:"quoteReqId"`
-	Account    string `json:"account"`
-	Side       string `json:"side"`
-	Symbol     string `json:"symbol"`
-	OrderQty   string `json:"orderQty"`
-	Price      string `json:"price"`
+	QuoteReqId     string `json:"quoteReqId"`
+	Account        string `json:"account"`
+	Side           string `json:"side"`
+	Symbol         string `json:"symbol"`
+	OrderQty       string `json:"orderQty"`
+	Price          string `json:"price"`
+	QtyType        string `json:"qtyType,omitempty"`
+	Status         string `json:"status,omitempty"`
+	QuoteId        string `json:"quoteId,omitempty"`
+	QuotePx        string `json:"quotePx,omitempty"`
+	QuoteQty       string `json:"quoteQty,omitempty"`
+	ValidUntilTime string `json:"validUntilTime,omitempty"`
+	ClOrdId        string `json:"clOrdId,omitempty"`
+	OrderId        string `json:"orderId,omitempty"`
+	Text           string `json:"text,omitempty"`
+	CreatedAt      string `json:"createdAt,omitempty"`
+	UpdatedAt      string `json:"updatedAt,omitempty"`
 }
 
 type QuoteInfo struct {
@@ -47,4 +95,13 @@ type QuoteInfo struct {
 	BidSize        string `json:"bidSize,omitempty"`
 	OfferSize      string `json:"offerSize,omitempty"`
 	ValidUntilTime string `json:"validUntilTime"`
+	Status         string `json:"status,omitempty"`
+	ReceivedAt     string `json:"receivedAt,omitempty"`
 }
+
+const (
+	QuoteStatusOpen     = "OPEN"
+	QuoteStatusAccepted = "ACCEPTED"
+	QuoteStatusPassed   = "PASSED"
+	QuoteStatusExpired  = "EXPIRED"
+)
@@ -0,0 +1,144 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"fmt"
+
+	"prime-fix-go/constants"
+)
+
+const (
+	OrderStatusPendingNew      = "PENDING_NEW"
+	OrderStatusNew             = "NEW"
+	OrderStatusPartiallyFilled = "PARTIALLY_FILLED"
+	OrderStatusFilled          = "FILLED"
+	OrderStatusDoneForDay      = "DONE_FOR_DAY"
+	OrderStatusPendingCancel   = "PENDING_CANCEL"
+	OrderStatusCanceled        = "CANCELED"
+	OrderStatusRejected        = "REJECTED"
+	OrderStatusExpired         = "EXPIRED"
+	OrderStatusPendingReplace  = "PENDING_REPLACE"
+	OrderStatusReplaced        = "REPLACED"
+)
+
+var ordStatusFromFix = map[string]string{
+	constants.OrdStatusPendingNew:      OrderStatusPendingNew,
+	constants.OrdStatusNew:             OrderStatusNew,
+	constants.OrdStatusPartiallyFilled: OrderStatusPartiallyFilled,
+	constants.OrdStatusFilled:          OrderStatusFilled,
+	constants.OrdStatusDoneForDay:      OrderStatusDoneForDay,
+	constants.OrdStatusPendingCancel:   OrderStatusPendingCancel,
+	constants.OrdStatusCanceled:        OrderStatusCanceled,
+	constants.OrdStatusRejected:        OrderStatusRejected,
+	constants.OrdStatusExpired:         OrderStatusExpired,
+	constants.OrdStatusPendingReplace:  OrderStatusPendingReplace,
+	constants.OrdStatusReplaced:        OrderStatusReplaced,
+}
+
+// execTypeStatus is used when an ExecutionReport arrives without OrdStatus(39).
+var execTypeStatus = map[string]string{
+	constants.ExecTypePendingNew:     OrderStatusPendingNew,
+	constants.ExecTypeNew:            OrderStatusNew,
+	constants.ExecTypePartialFill:    OrderStatusPartiallyFilled,
+	constants.ExecTypeFill:           OrderStatusFilled,
+	constants.ExecTypeDoneForDay:     OrderStatusDoneForDay,
+	constants.ExecTypePendingCancel:  OrderStatusPendingCancel,
+	constants.ExecTypeCanceled:       OrderStatusCanceled,
+	constants.ExecTypeRejected:       OrderStatusRejected,
+	constants.ExecTypeExpired:        OrderStatusExpired,
+	constants.ExecTypePendingReplace: OrderStatusPendingReplace,
+	constants.ExecTypeReplaced:       OrderStatusReplaced,
+}
+
+// OutcomePostOnlyWouldCross marks a post-only order Prime refused because it
+// would have taken liquidity.
+const OutcomePostOnlyWouldCross = "POST_ONLY_WOULD_CROSS"
+
+// orderTransitions lists the statuses each status may move to. Repeating the
+// current status is always allowed, since status requests and restatements
+// report the order unchanged.
+var orderTransitions = map[string][]string{
+	OrderStatusPendingNew: {
+		OrderStatusNew, OrderStatusPartiallyFilled, OrderStatusFilled,
+		OrderStatusPendingCancel, OrderStatusCanceled, OrderStatusRejected, OrderStatusExpired,
+	},
+	OrderStatusNew: {
+		OrderStatusPartiallyFilled, OrderStatusFilled, OrderStatusDoneForDay,
+		OrderStatusPendingCancel, OrderStatusCanceled, OrderStatusExpired,
+		OrderStatusPendingReplace, OrderStatusReplaced,
+	},
+	OrderStatusPartiallyFilled: {
+		OrderStatusFilled, OrderStatusDoneForDay,
+		OrderStatusPendingCancel, OrderStatusCanceled, OrderStatusExpired,
+		OrderStatusPendingReplace, OrderStatusReplaced,
+	},
+	OrderStatusDoneForDay: {
+		OrderStatusNew, OrderStatusPartiallyFilled, OrderStatusFilled,
+		OrderStatusCanceled, OrderStatusExpired,
+	},
+	OrderStatusPendingCancel: {
+		OrderStatusNew, OrderStatusPartiallyFilled, OrderStatusFilled,
+		OrderStatusCanceled, OrderStatusExpired,
+	},
+	OrderStatusPendingReplace: {
+		OrderStatusNew, OrderStatusPartiallyFilled, OrderStatusFilled,
+		OrderStatusCanceled, OrderStatusExpired, OrderStatusReplaced, OrderStatusRejected,
+	},
+}
+
+// OrderStatusFromFix maps an ExecutionReport's OrdStatus(39), falling back to
+// ExecType(150), to an order lifecycle status. It returns "" when neither is known.
+func OrderStatusFromFix(ordStatus, execType string) string {
+	if status, ok := ordStatusFromFix[ordStatus]; ok {
+		return status
+	}
+	return execTypeStatus[execType]
+}
+
+// IsTerminalStatus reports whether no further ExecutionReports are expected.
+func IsTerminalStatus(status string) bool {
+	switch status {
+	case OrderStatusFilled, OrderStatusCanceled, OrderStatusRejected, OrderStatusExpired, OrderStatusReplaced:
+		return true
+	}
+	return false
+}
+
+// CanTransition reports whether an order may move from one status to another.
+// An order with no recorded status may move anywhere.
+func CanTransition(from, to string) bool {
+	if from == "" || from == to {
+		return true
+	}
+	for _, next := range orderTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Transition moves the order to status, leaving it unchanged and returning an
+// error when the lifecycle does not allow the move.
+func (o *OrderInfo) Transition(status string) error {
+	if !CanTransition(o.Status, status) {
+		return fmt.Errorf("illegal transition %s → %s", o.Status, status)
+	}
+	o.Status = status
+	return nil
+}
@@ -0,0 +1,72 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"prime-fix-go/constants"
+)
+
+func TestOrderStatusFromFix(t *testing.T) {
+	if s := OrderStatusFromFix(constants.OrdStatusPartiallyFilled, constants.ExecTypePartialFill); s != OrderStatusPartiallyFilled {
+		t.Errorf("Expected %s, got %s", OrderStatusPartiallyFilled, s)
+	}
+
+	if s := OrderStatusFromFix("", constants.ExecTypeCanceled); s != OrderStatusCanceled {
+		t.Errorf("Expected ExecType fallback %s, got %s", OrderStatusCanceled, s)
+	}
+
+	if s := OrderStatusFromFix("", ""); s != "" {
+		t.Errorf("Expected empty status, got %s", s)
+	}
+}
+
+func TestOrderLifecycle(t *testing.T) {
+	order := OrderInfo{ClOrdId: "1", Status: OrderStatusPendingNew}
+
+	for _, status := range []string{OrderStatusNew, OrderStatusPartiallyFilled, OrderStatusPartiallyFilled, OrderStatusFilled} {
+		if err := order.Transition(status); err != nil {
+			t.Fatalf("Transition to %s returned error: %v", status, err)
+		}
+	}
+
+	if err := order.Transition(OrderStatusNew); err == nil {
+		t.Error("Expected FILLED → NEW to be illegal")
+	}
+	if order.Status != OrderStatusFilled {
+		t.Errorf("Expected status to remain %s, got %s", OrderStatusFilled, order.Status)
+	}
+}
+
+func TestTerminalStatuses(t *testing.T) {
+	for _, status := range []string{OrderStatusFilled, OrderStatusCanceled, OrderStatusRejected, OrderStatusExpired} {
+		if !IsTerminalStatus(status) {
+			t.Errorf("Expected %s to be terminal", status)
+		}
+		if CanTransition(status, OrderStatusPartiallyFilled) {
+			t.Errorf("Expected %s → %s to be illegal", status, OrderStatusPartiallyFilled)
+		}
+	}
+
+	if CanTransition(OrderStatusNew, OrderStatusPendingNew) {
+		t.Error("Expected NEW → PENDING_NEW to be illegal")
+	}
+	if !CanTransition("", OrderStatusFilled) {
+		t.Error("Expected an untracked order to accept any status")
+	}
+}
@@ -0,0 +1,63 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import "fmt"
+
+const (
+	RfqStatusSent     = "SENT"
+	RfqStatusAcked    = "ACKED"
+	RfqStatusRejected = "REJECTED"
+	RfqStatusQuoted   = "QUOTED"
+	RfqStatusAccepted = "ACCEPTED"
+	RfqStatusExpired  = "EXPIRED"
+	RfqStatusFilled   = "FILLED"
+)
+
+// rfqTransitions lists the statuses each RFQ status may move to. A quote can
+// arrive before, or instead of, the Quote Acknowledgement.
+var rfqTransitions = map[string][]string{
+	RfqStatusSent:     {RfqStatusAcked, RfqStatusRejected, RfqStatusQuoted, RfqStatusExpired},
+	RfqStatusAcked:    {RfqStatusRejected, RfqStatusQuoted, RfqStatusExpired},
+	RfqStatusQuoted:   {RfqStatusAccepted, RfqStatusExpired},
+	RfqStatusAccepted: {RfqStatusFilled, RfqStatusRejected, RfqStatusExpired},
+}
+
+// IsTerminalRfqStatus reports whether the RFQ can no longer change.
+func IsTerminalRfqStatus(status string) bool {
+	switch status {
+	case RfqStatusRejected, RfqStatusExpired, RfqStatusFilled:
+		return true
+	}
+	return false
+}
+
+// Transition moves the RFQ to status, leaving it unchanged and returning an
+// error when the lifecycle does not allow the move.
+func (r *QuoteRequestInfo) Transition(status string) error {
+	if r.Status == "" || r.Status == status {
+		r.Status = status
+		return nil
+	}
+	for _, next := range rfqTransitions[r.Status] {
+		if next == status {
+			r.Status = status
+			return nil
+		}
+	}
+	return fmt.Errorf("illegal transition %s → %s", r.Status, status)
+}
@@ -0,0 +1,49 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import "testing"
+
+func TestRfqLifecycle(t *testing.T) {
+	rfq := QuoteRequestInfo{QuoteReqId: "qr-1", Status: RfqStatusSent}
+
+	for _, status := range []string{RfqStatusAcked, RfqStatusQuoted, RfqStatusQuoted, RfqStatusAccepted, RfqStatusFilled} {
+		if err := rfq.Transition(status); err != nil {
+			t.Fatalf("Transition to %s returned error: %v", status, err)
+		}
+	}
+
+	if err := rfq.Transition(RfqStatusExpired); err == nil {
+		t.Error("Expected FILLED → EXPIRED to be illegal")
+	}
+	if !IsTerminalRfqStatus(rfq.Status) {
+		t.Errorf("Expected %s to be terminal", rfq.Status)
+	}
+}
+
+func TestRfqQuotedWithoutAck(t *testing.T) {
+	rfq := QuoteRequestInfo{QuoteReqId: "qr-1", Status: RfqStatusSent}
+	if err := rfq.Transition(RfqStatusQuoted); err != nil {
+		t.Errorf("Expected SENT → QUOTED to be legal, got %v", err)
+	}
+	if err := rfq.Transition(RfqStatusAcked); err == nil {
+		t.Error("Expected QUOTED → ACKED to be illegal")
+	}
+	if rfq.Status != RfqStatusQuoted {
+		t.Errorf("Expected status to remain %s, got %s", RfqStatusQuoted, rfq.Status)
+	}
+}
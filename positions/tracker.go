@@ -0,0 +1,270 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package positions
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/shopspring/decimal"
+
+	"prime-fix-go/amount"
+	"prime-fix-go/constants"
+	"prime-fix-go/model"
+)
+
+const (
+	ModeFifo    = "FIFO"
+	ModeAvgCost = "AVG"
+
+	MarkSourceQuote = "quote"
+	MarkSourceFill  = "last fill"
+)
+
+// Position is the net holding in one symbol for one portfolio. NetBase is
+// signed (negative when short) and NetQuote is the signed quote-currency cash
+// flow of every fill. Realized P&L is kept for both FIFO and average-cost
+// accounting; fees are tracked separately and not deducted from either. All
+// amounts are exact decimals; only AvgCost is rounded, to amount.MaxScale
+// places.
+type Position struct {
+	Portfolio    string
+	Symbol       string
+	NetBase      decimal.Decimal
+	NetQuote     decimal.Decimal
+	AvgCost      decimal.Decimal
+	RealizedFifo decimal.Decimal
+	RealizedAvg  decimal.Decimal
+	Fees         decimal.Decimal
+	LastPx       decimal.Decimal
+	lots         []lot
+}
+
+// lot is an open FIFO lot; qty carries the sign of the position it opened.
+type lot struct {
+	qty decimal.Decimal
+	px  decimal.Decimal
+}
+
+// Mark is the latest price a position is valued at.
+type Mark struct {
+	Price  decimal.Decimal
+	Source string
+}
+
+type key struct {
+	portfolio string
+	symbol    string
+}
+
+// Tracker derives positions and P&L from executions.
+type Tracker struct {
+	mu        sync.RWMutex
+	positions map[key]*Position
+	marks     map[string]decimal.Decimal
+}
+
+func NewTracker() *Tracker {
+	return &Tracker{
+		positions: make(map[key]*Position),
+		marks:     make(map[string]decimal.Decimal),
+	}
+}
+
+// Reset drops every position, keeping marks.
+func (t *Tracker) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.positions = make(map[key]*Position)
+}
+
+// Apply books one execution. Fills without a portfolio are booked under
+// defaultPortfolio.
+func (t *Tracker) Apply(fill model.Fill, defaultPortfolio string) error {
+	qty, err := amount.Positive(fill.LastShares)
+	if err != nil {
+		return fmt.Errorf("fill %s: invalid LastShares %q: %v", fill.ExecId, fill.LastShares, err)
+	}
+	px, err := amount.Parse(fill.LastPx)
+	if err != nil {
+		return fmt.Errorf("fill %s: invalid LastPx %q: %v", fill.ExecId, fill.LastPx, err)
+	}
+	switch fill.Side {
+	case constants.SideBuyFix:
+	case constants.SideSellFix:
+		qty = qty.Neg()
+	default:
+		return fmt.Errorf("fill %s: unknown side %q", fill.ExecId, fill.Side)
+	}
+	var fee decimal.Decimal
+	if fill.Commission != "" {
+		if fee, err = amount.Parse(fill.Commission); err != nil {
+			return fmt.Errorf("fill %s: invalid Commission %q: %v", fill.ExecId, fill.Commission, err)
+		}
+	}
+
+	portfolio := fill.Account
+	if portfolio == "" {
+		portfolio = defaultPortfolio
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	k := key{portfolio: portfolio, symbol: fill.Symbol}
+	p, ok := t.positions[k]
+	if !ok {
+		p = &Position{Portfolio: portfolio, Symbol: fill.Symbol}
+		t.positions[k] = p
+	}
+	p.applyAvgCost(qty, px)
+	p.applyFifo(qty, px)
+	p.NetBase = p.NetBase.Add(qty)
+	p.NetQuote = p.NetQuote.Sub(qty.Mul(px))
+	p.Fees = p.Fees.Add(fee)
+	p.LastPx = px
+	return nil
+}
+
+func (p *Position) applyAvgCost(qty, px decimal.Decimal) {
+	held := p.NetBase.Abs()
+	if p.NetBase.IsZero() || sameSign(p.NetBase, qty) {
+		cost := p.AvgCost.Mul(held).Add(px.Mul(qty.Abs()))
+		p.AvgCost = amount.Div(cost, held.Add(qty.Abs()))
+		return
+	}
+	closed := decimal.Min(qty.Abs(), held)
+	p.RealizedAvg = p.RealizedAvg.Add(px.Sub(p.AvgCost).Mul(closed).Mul(sign(p.NetBase)))
+	switch qty.Abs().Cmp(held) {
+	case 1:
+		// The fill flipped the position; the remainder opens at px.
+		p.AvgCost = px
+	case 0:
+		p.AvgCost = decimal.Zero
+	}
+}
+
+func (p *Position) applyFifo(qty, px decimal.Decimal) {
+	for len(p.lots) > 0 && !qty.IsZero() && !sameSign(p.lots[0].qty, qty) {
+		head := &p.lots[0]
+		lotSign := sign(head.qty)
+		closed := decimal.Min(qty.Abs(), head.qty.Abs())
+		p.RealizedFifo = p.RealizedFifo.Add(px.Sub(head.px).Mul(closed).Mul(lotSign))
+		head.qty = head.qty.Sub(closed.Mul(lotSign))
+		qty = qty.Add(closed.Mul(lotSign))
+		if head.qty.IsZero() {
+			p.lots = p.lots[1:]
+		}
+	}
+	if !qty.IsZero() {
+		p.lots = append(p.lots, lot{qty: qty, px: px})
+	}
+}
+
+// Mark records the latest price for symbol, used to value open positions.
+func (t *Tracker) Mark(symbol string, price decimal.Decimal) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.marks[symbol] = price
+}
+
+// MarkFor returns the latest quote price for symbol, falling back to the last
+// traded price when no quote has been seen.
+func (t *Tracker) MarkFor(symbol string) (Mark, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.markLocked(symbol)
+}
+
+func (t *Tracker) markLocked(symbol string) (Mark, bool) {
+	if px, ok := t.marks[symbol]; ok {
+		return Mark{Price: px, Source: MarkSourceQuote}, true
+	}
+	var last decimal.Decimal
+	for k, p := range t.positions {
+		if k.symbol == symbol && !p.LastPx.IsZero() {
+			last = p.LastPx
+		}
+	}
+	if last.IsZero() {
+		return Mark{}, false
+	}
+	return Mark{Price: last, Source: MarkSourceFill}, true
+}
+
+// Unrealized values the open position at mark under the given mode.
+func (p Position) Unrealized(mode string, mark decimal.Decimal) decimal.Decimal {
+	if mode == ModeFifo {
+		var pnl decimal.Decimal
+		for _, l := range p.lots {
+			pnl = pnl.Add(mark.Sub(l.px).Mul(l.qty))
+		}
+		return pnl
+	}
+	return mark.Sub(p.AvgCost).Mul(p.NetBase)
+}
+
+// Realized returns realized P&L under the given mode.
+func (p Position) Realized(mode string) decimal.Decimal {
+	if mode == ModeFifo {
+		return p.RealizedFifo
+	}
+	return p.RealizedAvg
+}
+
+// FifoCost is the average price of the open FIFO lots.
+func (p Position) FifoCost() decimal.Decimal {
+	var qty, cost decimal.Decimal
+	for _, l := range p.lots {
+		qty = qty.Add(l.qty)
+		cost = cost.Add(l.qty.Mul(l.px))
+	}
+	if qty.IsZero() {
+		return decimal.Zero
+	}
+	return amount.Div(cost, qty)
+}
+
+// Positions returns a copy of every position, ordered by symbol then portfolio.
+func (t *Tracker) Positions() []Position {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	out := make([]Position, 0, len(t.positions))
+	for _, p := range t.positions {
+		cp := *p
+		cp.lots = append([]lot(nil), p.lots...)
+		out = append(out, cp)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Symbol != out[j].Symbol {
+			return out[i].Symbol < out[j].Symbol
+		}
+		return out[i].Portfolio < out[j].Portfolio
+	})
+	return out
+}
+
+func sameSign(a, b decimal.Decimal) bool {
+	return a.Sign() != 0 && a.Sign() == b.Sign()
+}
+
+func sign(v decimal.Decimal) decimal.Decimal {
+	if v.IsNegative() {
+		return decimal.NewFromInt(-1)
+	}
+	return decimal.NewFromInt(1)
+}
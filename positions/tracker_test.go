@@ -0,0 +1,131 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package positions
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"prime-fix-go/constants"
+	"prime-fix-go/model"
+)
+
+func fill(id, side, qty, px string) model.Fill {
+	return model.Fill{ExecId: id, Side: side, Symbol: "BTC-USD", LastShares: qty, LastPx: px}
+}
+
+func dec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func equal(a decimal.Decimal, b string) bool {
+	return a.Equal(dec(b))
+}
+
+func TestFifoAndAverageCost(t *testing.T) {
+	tracker := NewTracker()
+	for _, f := range []model.Fill{
+		fill("1", constants.SideBuyFix, "1", "100"),
+		fill("2", constants.SideBuyFix, "1", "200"),
+		fill("3", constants.SideSellFix, "1", "250"),
+	} {
+		if err := tracker.Apply(f, "portfolio"); err != nil {
+			t.Fatalf("Apply returned error: %v", err)
+		}
+	}
+
+	positions := tracker.Positions()
+	if len(positions) != 1 {
+		t.Fatalf("Expected 1 position, got %d", len(positions))
+	}
+	p := positions[0]
+
+	if !equal(p.NetBase, "1") {
+		t.Errorf("Expected net base 1, got %v", p.NetBase)
+	}
+	if !equal(p.NetQuote, "-50") {
+		t.Errorf("Expected net quote -50, got %v", p.NetQuote)
+	}
+	if !equal(p.RealizedFifo, "150") {
+		t.Errorf("Expected FIFO realized 150, got %v", p.RealizedFifo)
+	}
+	if !equal(p.RealizedAvg, "100") {
+		t.Errorf("Expected average-cost realized 100, got %v", p.RealizedAvg)
+	}
+	if !equal(p.Unrealized(ModeFifo, dec("300")), "100") {
+		t.Errorf("Expected FIFO unrealized 100, got %v", p.Unrealized(ModeFifo, dec("300")))
+	}
+	if !equal(p.Unrealized(ModeAvgCost, dec("300")), "150") {
+		t.Errorf("Expected average-cost unrealized 150, got %v", p.Unrealized(ModeAvgCost, dec("300")))
+	}
+}
+
+func TestPositionFlip(t *testing.T) {
+	tracker := NewTracker()
+	_ = tracker.Apply(fill("1", constants.SideBuyFix, "1", "100"), "portfolio")
+	_ = tracker.Apply(fill("2", constants.SideSellFix, "3", "120"), "portfolio")
+
+	p := tracker.Positions()[0]
+	if !equal(p.NetBase, "-2") {
+		t.Errorf("Expected net base -2, got %v", p.NetBase)
+	}
+	if !equal(p.RealizedFifo, "20") || !equal(p.RealizedAvg, "20") {
+		t.Errorf("Expected realized 20 in both modes, got FIFO %v AVG %v", p.RealizedFifo, p.RealizedAvg)
+	}
+	if !equal(p.AvgCost, "120") || !equal(p.FifoCost(), "120") {
+		t.Errorf("Expected short opened at 120, got AVG %v FIFO %v", p.AvgCost, p.FifoCost())
+	}
+	if !equal(p.Unrealized(ModeFifo, dec("110")), "20") {
+		t.Errorf("Expected short unrealized 20, got %v", p.Unrealized(ModeFifo, dec("110")))
+	}
+}
+
+func TestPositionsPerPortfolio(t *testing.T) {
+	tracker := NewTracker()
+	a := fill("1", constants.SideBuyFix, "1", "100")
+	a.Account = "a"
+	_ = tracker.Apply(a, "default")
+	_ = tracker.Apply(fill("2", constants.SideBuyFix, "2", "100"), "default")
+
+	positions := tracker.Positions()
+	if len(positions) != 2 {
+		t.Fatalf("Expected 2 positions, got %d", len(positions))
+	}
+	if positions[0].Portfolio != "a" || positions[1].Portfolio != "default" {
+		t.Errorf("Expected portfolios a and default, got %s and %s", positions[0].Portfolio, positions[1].Portfolio)
+	}
+}
+
+func TestFractionalFillsAreExact(t *testing.T) {
+	tracker := NewTracker()
+	for i := 0; i < 10; i++ {
+		_ = tracker.Apply(fill("b", constants.SideBuyFix, "0.1", "30000.1"), "portfolio")
+	}
+	_ = tracker.Apply(fill("s", constants.SideSellFix, "1", "30000.3"), "portfolio")
+
+	p := tracker.Positions()[0]
+	if !p.NetBase.IsZero() {
+		t.Errorf("Expected a flat position, got %v", p.NetBase)
+	}
+	if !equal(p.RealizedFifo, "0.2") || !equal(p.RealizedAvg, "0.2") {
+		t.Errorf("Expected realized 0.2 in both modes, got FIFO %v AVG %v", p.RealizedFifo, p.RealizedAvg)
+	}
+	if !equal(p.NetQuote, "0.2") {
+		t.Errorf("Expected net quote 0.2, got %v", p.NetQuote)
+	}
+}
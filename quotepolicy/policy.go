@@ -0,0 +1,174 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package quotepolicy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"prime-fix-go/constants"
+)
+
+// DefaultSymbol keys the rule applied to symbols without their own entry.
+const DefaultSymbol = "*"
+
+// Rule decides whether a quote may be accepted without asking. Zero disables
+// a limit, except that a quote is never accepted beyond the RFQ limit price by
+// more than ToleranceBps.
+type Rule struct {
+	Enabled      *bool   `json:"enabled,omitempty"`
+	ToleranceBps float64 `json:"toleranceBps,omitempty"`
+	MinValidity  string  `json:"minValidity,omitempty"`
+	MaxNotional  float64 `json:"maxNotional,omitempty"`
+}
+
+// Policy holds the auto-accept rules, keyed by symbol with "*" as the default.
+type Policy struct {
+	Symbols map[string]Rule `json:"symbols,omitempty"`
+}
+
+// Quote is a received quote together with the RFQ it answers. Side is the
+// RFQ side, Limit its limit price.
+type Quote struct {
+	Symbol     string
+	Side       string
+	Price      decimal.Decimal
+	Qty        decimal.Decimal
+	Limit      decimal.Decimal
+	ValidUntil time.Time
+}
+
+// Decision is the outcome of evaluating a quote; Reason explains it.
+type Decision struct {
+	Accept bool
+	Reason string
+}
+
+// Default accepts any quote at or better than the RFQ limit price.
+func Default() *Policy {
+	return &Policy{}
+}
+
+// Load reads a policy from a JSON file, validating every rule.
+func Load(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var p Policy
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("invalid auto-accept policy %s: %w", path, err)
+	}
+	for symbol, rule := range p.Symbols {
+		if _, err := rule.minValidity(); err != nil {
+			return nil, fmt.Errorf("invalid auto-accept policy %s: %s: %w", path, symbol, err)
+		}
+		if rule.ToleranceBps < 0 || rule.MaxNotional < 0 {
+			return nil, fmt.Errorf("invalid auto-accept policy %s: %s: limits must not be negative", path, symbol)
+		}
+	}
+	return &p, nil
+}
+
+// Evaluate decides whether q may be accepted at now.
+func (p *Policy) Evaluate(q Quote, now time.Time) Decision {
+	rule := p.forSymbol(q.Symbol)
+	if rule.Enabled != nil && !*rule.Enabled {
+		return hold("auto-accept is disabled for %s", q.Symbol)
+	}
+	if !q.Price.IsPositive() {
+		return hold("quote has no price")
+	}
+	if !q.Limit.IsPositive() {
+		return hold("RFQ has no limit price")
+	}
+
+	one := decimal.NewFromInt(1)
+	tolerance := decimal.NewFromFloat(rule.ToleranceBps).Div(decimal.NewFromInt(10000))
+	switch strings.ToUpper(q.Side) {
+	case constants.SideBuy:
+		if worst := q.Limit.Mul(one.Add(tolerance)); q.Price.GreaterThan(worst) {
+			return hold("offer %s is above limit %s (tolerance %g bps)", q.Price, q.Limit, rule.ToleranceBps)
+		}
+	case constants.SideSell:
+		if worst := q.Limit.Mul(one.Sub(tolerance)); q.Price.LessThan(worst) {
+			return hold("bid %s is below limit %s (tolerance %g bps)", q.Price, q.Limit, rule.ToleranceBps)
+		}
+	default:
+		return hold("unknown RFQ side %q", q.Side)
+	}
+
+	minValidity, _ := rule.minValidity()
+	if minValidity > 0 {
+		if q.ValidUntil.IsZero() {
+			return hold("quote has no ValidUntilTime")
+		}
+		if remaining := q.ValidUntil.Sub(now); remaining < minValidity {
+			return hold("%s left to accept, need at least %s", remaining.Round(time.Millisecond), minValidity)
+		}
+	}
+
+	notional := q.Price.Mul(q.Qty)
+	if rule.MaxNotional > 0 && notional.GreaterThan(decimal.NewFromFloat(rule.MaxNotional)) {
+		return hold("notional %s exceeds cap %.2f", notional.StringFixed(2), rule.MaxNotional)
+	}
+
+	return Decision{Accept: true, Reason: fmt.Sprintf("%s is within limit %s, notional %s", q.Price, q.Limit, notional.StringFixed(2))}
+}
+
+func hold(format string, args ...any) Decision {
+	return Decision{Reason: fmt.Sprintf(format, args...)}
+}
+
+func (r Rule) minValidity() (time.Duration, error) {
+	if r.MinValidity == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(r.MinValidity)
+	if err != nil {
+		return 0, fmt.Errorf("minValidity: %w", err)
+	}
+	return d, nil
+}
+
+// forSymbol merges the symbol's rule over the default.
+func (p *Policy) forSymbol(symbol string) Rule {
+	merged := p.Symbols[DefaultSymbol]
+	for s, r := range p.Symbols {
+		if !strings.EqualFold(s, symbol) {
+			continue
+		}
+		if r.Enabled != nil {
+			merged.Enabled = r.Enabled
+		}
+		if r.ToleranceBps != 0 {
+			merged.ToleranceBps = r.ToleranceBps
+		}
+		if r.MinValidity != "" {
+			merged.MinValidity = r.MinValidity
+		}
+		if r.MaxNotional != 0 {
+			merged.MaxNotional = r.MaxNotional
+		}
+	}
+	return merged
+}
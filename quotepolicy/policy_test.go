@@ -0,0 +1,84 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package quotepolicy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var d = decimal.RequireFromString
+
+func TestEvaluate(t *testing.T) {
+	disabled := false
+	policy := &Policy{Symbols: map[string]Rule{
+		DefaultSymbol: {MinValidity: "2s", MaxNotional: 1000},
+		"ETH-USD":     {ToleranceBps: 50},
+		"DOGE-USD":    {Enabled: &disabled},
+	}}
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	valid := now.Add(10 * time.Second)
+
+	tests := []struct {
+		name   string
+		quote  Quote
+		accept bool
+	}{
+		{"buy at limit", Quote{Symbol: "BTC-USD", Side: "BUY", Price: d("100"), Qty: d("1"), Limit: d("100"), ValidUntil: valid}, true},
+		{"buy above limit", Quote{Symbol: "BTC-USD", Side: "BUY", Price: d("100.01"), Qty: d("1"), Limit: d("100"), ValidUntil: valid}, false},
+		{"sell above limit", Quote{Symbol: "BTC-USD", Side: "SELL", Price: d("101"), Qty: d("1"), Limit: d("100"), ValidUntil: valid}, true},
+		{"sell below limit", Quote{Symbol: "BTC-USD", Side: "SELL", Price: d("99"), Qty: d("1"), Limit: d("100"), ValidUntil: valid}, false},
+		{"within tolerance", Quote{Symbol: "ETH-USD", Side: "BUY", Price: d("100.4"), Qty: d("1"), Limit: d("100"), ValidUntil: valid}, true},
+		{"beyond tolerance", Quote{Symbol: "ETH-USD", Side: "BUY", Price: d("100.6"), Qty: d("1"), Limit: d("100"), ValidUntil: valid}, false},
+		{"too little validity", Quote{Symbol: "BTC-USD", Side: "BUY", Price: d("100"), Qty: d("1"), Limit: d("100"), ValidUntil: now.Add(time.Second)}, false},
+		{"no validity", Quote{Symbol: "BTC-USD", Side: "BUY", Price: d("100"), Qty: d("1"), Limit: d("100")}, false},
+		{"notional cap", Quote{Symbol: "BTC-USD", Side: "BUY", Price: d("100"), Qty: d("11"), Limit: d("100"), ValidUntil: valid}, false},
+		{"disabled symbol", Quote{Symbol: "DOGE-USD", Side: "BUY", Price: d("1"), Qty: d("1"), Limit: d("1"), ValidUntil: valid}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := policy.Evaluate(tt.quote, now)
+			if d.Accept != tt.accept {
+				t.Errorf("Expected accept=%v, got %v (%s)", tt.accept, d.Accept, d.Reason)
+			}
+			if d.Reason == "" {
+				t.Error("Expected a reason")
+			}
+		})
+	}
+}
+
+func TestDefaultRequiresLimit(t *testing.T) {
+	d := Default().Evaluate(Quote{Symbol: "BTC-USD", Side: "BUY", Price: d("100"), Qty: d("1")}, time.Now())
+	if d.Accept {
+		t.Error("Expected a quote without an RFQ limit to be held")
+	}
+}
+
+func TestLoadRejectsBadDuration(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.json")
+	if err := os.WriteFile(path, []byte(`{"symbols": {"*": {"minValidity": "soon"}}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Expected an error for an invalid minValidity")
+	}
+}
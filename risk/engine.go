@@ -0,0 +1,226 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package risk
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	RuleSymbol        = "symbol"
+	RuleOrderQty      = "max_order_qty"
+	RuleOrderNotional = "max_order_notional"
+	RulePriceCollar   = "price_collar"
+	RuleOpenOrders    = "max_open_orders"
+	RuleDailyNotional = "max_daily_notional"
+
+	// DefaultSymbol keys the limits applied to symbols without their own entry.
+	DefaultSymbol = "*"
+)
+
+// SymbolLimits caps a single order. Zero disables a limit.
+type SymbolLimits struct {
+	MaxOrderQty      decimal.Decimal `json:"maxOrderQty"`
+	MaxOrderNotional decimal.Decimal `json:"maxOrderNotional"`
+	PriceCollarBps   decimal.Decimal `json:"priceCollarBps"`
+}
+
+// Limits is the risk configuration as loaded from the limits file. Zero
+// disables a limit; an empty AllowSymbols allows every symbol not denied.
+type Limits struct {
+	AllowSymbols     []string                `json:"allowSymbols,omitempty"`
+	DenySymbols      []string                `json:"denySymbols,omitempty"`
+	MaxOpenOrders    int                     `json:"maxOpenOrders,omitempty"`
+	MaxDailyNotional decimal.Decimal         `json:"maxDailyNotional"`
+	Symbols          map[string]SymbolLimits `json:"symbols,omitempty"`
+}
+
+// Order is an outbound order as seen by the risk checks. Price is the limit
+// price, or zero for orders without one.
+type Order struct {
+	Symbol  string
+	QtyType string
+	Qty     decimal.Decimal
+	Price   decimal.Decimal
+}
+
+// State is the client state the checks are evaluated against. RefPrice is the
+// last known quote price for the symbol, or zero when none has been seen.
+type State struct {
+	OpenOrders    int
+	DailyNotional decimal.Decimal
+	RefPrice      decimal.Decimal
+}
+
+// Violation explains why an order was blocked.
+type Violation struct {
+	Rule   string
+	Reason string
+}
+
+func (v *Violation) Error() string {
+	return fmt.Sprintf("risk check %s failed: %s", v.Rule, v.Reason)
+}
+
+// Engine evaluates orders against limits loaded from a JSON file.
+type Engine struct {
+	mu     sync.RWMutex
+	path   string
+	limits Limits
+}
+
+func NewEngine(limits Limits) *Engine {
+	return &Engine{limits: limits}
+}
+
+// LoadEngine reads limits from path; Reload re-reads the same file.
+func LoadEngine(path string) (*Engine, error) {
+	e := &Engine{path: path}
+	if err := e.Reload(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (e *Engine) Reload() error {
+	if e.path == "" {
+		return fmt.Errorf("risk limits were not loaded from a file")
+	}
+	data, err := os.ReadFile(e.path)
+	if err != nil {
+		return err
+	}
+	var limits Limits
+	if err := json.Unmarshal(data, &limits); err != nil {
+		return fmt.Errorf("invalid risk limits %s: %w", e.path, err)
+	}
+	e.mu.Lock()
+	e.limits = limits
+	e.mu.Unlock()
+	return nil
+}
+
+func (e *Engine) Limits() Limits {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.limits
+}
+
+func (e *Engine) Path() string {
+	return e.path
+}
+
+// Check returns a *Violation for the first limit the order breaks, or nil.
+func (e *Engine) Check(order Order, state State) error {
+	e.mu.RLock()
+	limits := e.limits
+	e.mu.RUnlock()
+
+	if containsSymbol(limits.DenySymbols, order.Symbol) {
+		return &Violation{RuleSymbol, order.Symbol + " is on the deny list"}
+	}
+	if len(limits.AllowSymbols) > 0 && !containsSymbol(limits.AllowSymbols, order.Symbol) {
+		return &Violation{RuleSymbol, order.Symbol + " is not on the allow list"}
+	}
+
+	symbolLimits := limits.forSymbol(order.Symbol)
+
+	price := order.Price
+	if price.IsZero() {
+		price = state.RefPrice
+	}
+	// Without a price a quote quantity has no base quantity and a base
+	// quantity has no notional.
+	isQuote := strings.EqualFold(order.QtyType, "QUOTE")
+	hasPrice := price.IsPositive()
+	baseQty, notional := order.Qty, order.Qty
+	switch {
+	case isQuote && hasPrice:
+		baseQty = order.Qty.Div(price)
+	case !isQuote && hasPrice:
+		notional = order.Qty.Mul(price)
+	}
+
+	if symbolLimits.MaxOrderQty.IsPositive() {
+		if isQuote && !hasPrice {
+			return &Violation{RuleOrderQty, "no reference price to convert the quote quantity"}
+		}
+		if baseQty.Cmp(symbolLimits.MaxOrderQty) > 0 {
+			return &Violation{RuleOrderQty, fmt.Sprintf("quantity %s exceeds %s for %s", baseQty, symbolLimits.MaxOrderQty, order.Symbol)}
+		}
+	}
+	if (symbolLimits.MaxOrderNotional.IsPositive() || limits.MaxDailyNotional.IsPositive()) && !isQuote && !hasPrice {
+		return &Violation{RuleOrderNotional, "no reference price to compute notional"}
+	}
+	if symbolLimits.MaxOrderNotional.IsPositive() && notional.Cmp(symbolLimits.MaxOrderNotional) > 0 {
+		return &Violation{RuleOrderNotional, fmt.Sprintf("notional %s exceeds %s for %s",
+			notional.StringFixed(2), symbolLimits.MaxOrderNotional.StringFixed(2), order.Symbol)}
+	}
+
+	if symbolLimits.PriceCollarBps.IsPositive() && order.Price.IsPositive() && state.RefPrice.IsPositive() {
+		deviation := order.Price.Sub(state.RefPrice).Abs().Div(state.RefPrice).Mul(decimal.NewFromInt(10000))
+		if deviation.Cmp(symbolLimits.PriceCollarBps) > 0 {
+			return &Violation{RulePriceCollar, fmt.Sprintf("price %s is %s bps from last quote %s (limit %s bps)",
+				order.Price, deviation.StringFixed(0), state.RefPrice, symbolLimits.PriceCollarBps)}
+		}
+	}
+
+	if limits.MaxOpenOrders > 0 && state.OpenOrders+1 > limits.MaxOpenOrders {
+		return &Violation{RuleOpenOrders, fmt.Sprintf("%d open orders already (limit %d)", state.OpenOrders, limits.MaxOpenOrders)}
+	}
+
+	if daily := state.DailyNotional.Add(notional); limits.MaxDailyNotional.IsPositive() && daily.Cmp(limits.MaxDailyNotional) > 0 {
+		return &Violation{RuleDailyNotional, fmt.Sprintf("traded %s today; this order would bring it to %s (limit %s)",
+			state.DailyNotional.StringFixed(2), daily.StringFixed(2), limits.MaxDailyNotional.StringFixed(2))}
+	}
+	return nil
+}
+
+// forSymbol merges the symbol's limits over the defaults.
+func (l Limits) forSymbol(symbol string) SymbolLimits {
+	merged := l.Symbols[DefaultSymbol]
+	for s, sl := range l.Symbols {
+		if !strings.EqualFold(s, symbol) {
+			continue
+		}
+		if !sl.MaxOrderQty.IsZero() {
+			merged.MaxOrderQty = sl.MaxOrderQty
+		}
+		if !sl.MaxOrderNotional.IsZero() {
+			merged.MaxOrderNotional = sl.MaxOrderNotional
+		}
+		if !sl.PriceCollarBps.IsZero() {
+			merged.PriceCollarBps = sl.PriceCollarBps
+		}
+	}
+	return merged
+}
+
+func containsSymbol(symbols []string, symbol string) bool {
+	for _, s := range symbols {
+		if strings.EqualFold(s, symbol) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package risk
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func dec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func ruleOf(t *testing.T, err error) string {
+	t.Helper()
+	if err == nil {
+		return ""
+	}
+	var v *Violation
+	if !errors.As(err, &v) {
+		t.Fatalf("Expected *Violation, got %T: %v", err, err)
+	}
+	return v.Rule
+}
+
+func TestCheck(t *testing.T) {
+	engine := NewEngine(Limits{
+		DenySymbols:      []string{"DOGE-USD"},
+		MaxOpenOrders:    2,
+		MaxDailyNotional: dec("10000"),
+		Symbols: map[string]SymbolLimits{
+			DefaultSymbol: {MaxOrderQty: dec("10"), PriceCollarBps: dec("500")},
+			"BTC-USD":     {MaxOrderQty: dec("1"), MaxOrderNotional: dec("5000")},
+		},
+	})
+
+	tests := []struct {
+		name  string
+		order Order
+		state State
+		rule  string
+	}{
+		{"allowed", Order{Symbol: "BTC-USD", QtyType: "BASE", Qty: dec("0.05"), Price: dec("100000")}, State{}, ""},
+		{"denied symbol", Order{Symbol: "doge-usd", QtyType: "BASE", Qty: dec("1"), Price: dec("1")}, State{}, RuleSymbol},
+		{"symbol qty", Order{Symbol: "BTC-USD", QtyType: "BASE", Qty: dec("2"), Price: dec("100")}, State{}, RuleOrderQty},
+		{"default qty", Order{Symbol: "ETH-USD", QtyType: "BASE", Qty: dec("11"), Price: dec("1")}, State{}, RuleOrderQty},
+		{"quote qty converted", Order{Symbol: "BTC-USD", QtyType: "QUOTE", Qty: dec("4000"), Price: dec("2000")}, State{}, RuleOrderQty},
+		{"notional", Order{Symbol: "BTC-USD", QtyType: "BASE", Qty: dec("0.1"), Price: dec("100000")}, State{}, RuleOrderNotional},
+		{"market uses ref price", Order{Symbol: "BTC-USD", QtyType: "BASE", Qty: dec("0.1")}, State{RefPrice: dec("100000")}, RuleOrderNotional},
+		{"no price for notional", Order{Symbol: "BTC-USD", QtyType: "BASE", Qty: dec("0.1")}, State{}, RuleOrderNotional},
+		{"collar", Order{Symbol: "ETH-USD", QtyType: "BASE", Qty: dec("1"), Price: dec("110")}, State{RefPrice: dec("100")}, RulePriceCollar},
+		{"inside collar", Order{Symbol: "ETH-USD", QtyType: "BASE", Qty: dec("1"), Price: dec("104")}, State{RefPrice: dec("100")}, ""},
+		{"open orders", Order{Symbol: "ETH-USD", QtyType: "BASE", Qty: dec("1"), Price: dec("1")}, State{OpenOrders: 2}, RuleOpenOrders},
+		{"daily notional", Order{Symbol: "ETH-USD", QtyType: "BASE", Qty: dec("5"), Price: dec("1000")}, State{DailyNotional: dec("6000")}, RuleDailyNotional},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rule := ruleOf(t, engine.Check(tt.order, tt.state)); rule != tt.rule {
+				t.Errorf("Expected rule %q, got %q", tt.rule, rule)
+			}
+		})
+	}
+}
+
+func TestCheckAtExactLimits(t *testing.T) {
+	engine := NewEngine(Limits{
+		MaxDailyNotional: dec("0.9"),
+		Symbols: map[string]SymbolLimits{
+			DefaultSymbol: {MaxOrderQty: dec("0.3"), MaxOrderNotional: dec("0.3")},
+		},
+	})
+	// 0.1+0.2 is 0.30000000000000004 in float64.
+	qty := dec("0.1").Add(dec("0.2"))
+
+	tests := []struct {
+		name  string
+		order Order
+		state State
+		rule  string
+	}{
+		{"qty at limit", Order{Symbol: "BTC-USD", QtyType: "BASE", Qty: qty, Price: dec("1")}, State{}, ""},
+		{"quote qty at limit", Order{Symbol: "BTC-USD", QtyType: "QUOTE", Qty: dec("0.3"), Price: dec("1")}, State{}, ""},
+		{"notional at limit", Order{Symbol: "BTC-USD", QtyType: "BASE", Qty: dec("0.1"), Price: dec("3")}, State{}, ""},
+		{"daily notional at limit", Order{Symbol: "BTC-USD", QtyType: "BASE", Qty: qty, Price: dec("1")}, State{DailyNotional: dec("0.6")}, ""},
+		{"just over", Order{Symbol: "BTC-USD", QtyType: "BASE", Qty: dec("0.3000000001"), Price: dec("1")}, State{}, RuleOrderQty},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rule := ruleOf(t, engine.Check(tt.order, tt.state)); rule != tt.rule {
+				t.Errorf("Expected rule %q, got %q", tt.rule, rule)
+			}
+		})
+	}
+}
+
+func TestAllowList(t *testing.T) {
+	engine := NewEngine(Limits{AllowSymbols: []string{"BTC-USD"}})
+	if err := engine.Check(Order{Symbol: "BTC-USD", Qty: dec("1")}, State{}); err != nil {
+		t.Errorf("Expected BTC-USD allowed, got %v", err)
+	}
+	if rule := ruleOf(t, engine.Check(Order{Symbol: "ETH-USD", Qty: dec("1")}, State{})); rule != RuleSymbol {
+		t.Errorf("Expected rule %q, got %q", RuleSymbol, rule)
+	}
+}
+
+func TestReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "risk.json")
+	if err := os.WriteFile(path, []byte(`{"maxOpenOrders": 1}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	engine, err := LoadEngine(path)
+	if err != nil {
+		t.Fatalf("LoadEngine returned error: %v", err)
+	}
+	if rule := ruleOf(t, engine.Check(Order{Symbol: "BTC-USD", Qty: dec("1")}, State{OpenOrders: 1})); rule != RuleOpenOrders {
+		t.Errorf("Expected rule %q, got %q", RuleOpenOrders, rule)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"maxOpenOrders": 5}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.Reload(); err != nil {
+		t.Fatalf("Reload returned error: %v", err)
+	}
+	if err := engine.Check(Order{Symbol: "BTC-USD", Qty: dec("1")}, State{OpenOrders: 1}); err != nil {
+		t.Errorf("Expected order allowed after reload, got %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte(`{`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.Reload(); err == nil {
+		t.Error("Expected error reloading invalid limits")
+	}
+	if engine.Limits().MaxOpenOrders != 5 {
+		t.Error("Expected previous limits kept after failed reload")
+	}
+}
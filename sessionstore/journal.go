@@ -0,0 +1,344 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sessionstore
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/quickfix/config"
+)
+
+type journalStoreFactory struct {
+	settings *quickfix.Settings
+}
+
+// NewJournalStoreFactory returns a pure-Go message store that keeps each
+// session in a single append-only file under FileStorePath. Sequence numbers
+// and sent messages are appended as they change and replayed on start-up, so
+// a restart resumes the session where it stopped.
+func NewJournalStoreFactory(settings *quickfix.Settings) quickfix.MessageStoreFactory {
+	return journalStoreFactory{settings: settings}
+}
+
+func (f journalStoreFactory) Create(sessionID quickfix.SessionID) (quickfix.MessageStore, error) {
+	sessionSettings, ok := f.settings.SessionSettings()[sessionID]
+	if !ok {
+		sessionSettings = f.settings.GlobalSettings()
+	}
+	dir, err := sessionSettings.Setting(config.FileStorePath)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	name := fmt.Sprintf("%s-%s-%s.journal", sessionID.BeginString, sessionID.SenderCompID, sessionID.TargetCompID)
+	return openJournalStore(filepath.Join(dir, name))
+}
+
+// msgLoc is where a stored message's bytes sit in the journal.
+type msgLoc struct {
+	offset int64
+	length int
+}
+
+// journalStore implements quickfix.MessageStore. Records are text lines:
+//
+//	C <unix nanos>        creation time
+//	S <seq>               next sender MsgSeqNum
+//	T <seq>               next target MsgSeqNum
+//	M <seq> <length>      followed by the raw message and a newline
+type journalStore struct {
+	mu           sync.Mutex
+	file         *os.File
+	senderSeq    int
+	targetSeq    int
+	creationTime time.Time
+	messages     map[int]msgLoc
+}
+
+func openJournalStore(path string) (*journalStore, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	s := &journalStore{file: f}
+	if err := s.load(); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to replay %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// load replays the journal, truncating a record torn by a crash.
+func (s *journalStore) load() error {
+	s.senderSeq, s.targetSeq = 1, 1
+	s.creationTime = time.Time{}
+	s.messages = make(map[int]msgLoc)
+
+	if _, err := s.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	reader := bufio.NewReader(s.file)
+	var offset int64
+	for {
+		line, err := reader.ReadString('\n')
+		if err == io.EOF {
+			if line != "" {
+				if err := s.file.Truncate(offset); err != nil {
+					return err
+				}
+			}
+			break
+		}
+		if err != nil {
+			return err
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return fmt.Errorf("malformed record at offset %d", offset)
+		}
+		next := offset + int64(len(line))
+		switch fields[0] {
+		case "C":
+			nanos, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return err
+			}
+			s.creationTime = time.Unix(0, nanos).UTC()
+		case "S":
+			if s.senderSeq, err = strconv.Atoi(fields[1]); err != nil {
+				return err
+			}
+		case "T":
+			if s.targetSeq, err = strconv.Atoi(fields[1]); err != nil {
+				return err
+			}
+		case "M":
+			if len(fields) < 3 {
+				return fmt.Errorf("malformed message record at offset %d", offset)
+			}
+			seq, err := strconv.Atoi(fields[1])
+			if err != nil {
+				return err
+			}
+			length, err := strconv.Atoi(fields[2])
+			if err != nil {
+				return err
+			}
+			if _, err := reader.Discard(length + 1); err != nil {
+				// The message body was cut short; drop the whole record.
+				if err := s.file.Truncate(offset); err != nil {
+					return err
+				}
+				return s.seekEnd()
+			}
+			s.messages[seq] = msgLoc{offset: next, length: length}
+			next += int64(length + 1)
+		default:
+			return fmt.Errorf("unknown record %q at offset %d", fields[0], offset)
+		}
+		offset = next
+	}
+
+	if s.creationTime.IsZero() {
+		s.creationTime = time.Now().UTC()
+		if err := s.appendLine("C %d", s.creationTime.UnixNano()); err != nil {
+			return err
+		}
+	}
+	return s.seekEnd()
+}
+
+func (s *journalStore) seekEnd() error {
+	_, err := s.file.Seek(0, io.SeekEnd)
+	return err
+}
+
+func (s *journalStore) appendLine(format string, args ...any) error {
+	if _, err := fmt.Fprintf(s.file, format+"\n", args...); err != nil {
+		return err
+	}
+	return s.file.Sync()
+}
+
+func (s *journalStore) NextSenderMsgSeqNum() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.senderSeq
+}
+
+func (s *journalStore) NextTargetMsgSeqNum() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.targetSeq
+}
+
+func (s *journalStore) IncrNextSenderMsgSeqNum() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.setSenderLocked(s.senderSeq + 1)
+}
+
+func (s *journalStore) IncrNextTargetMsgSeqNum() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.setTargetLocked(s.targetSeq + 1)
+}
+
+func (s *journalStore) SetNextSenderMsgSeqNum(next int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.setSenderLocked(next)
+}
+
+func (s *journalStore) SetNextTargetMsgSeqNum(next int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.setTargetLocked(next)
+}
+
+func (s *journalStore) setSenderLocked(next int) error {
+	if err := s.appendLine("S %d", next); err != nil {
+		return err
+	}
+	s.senderSeq = next
+	return nil
+}
+
+func (s *journalStore) setTargetLocked(next int) error {
+	if err := s.appendLine("T %d", next); err != nil {
+		return err
+	}
+	s.targetSeq = next
+	return nil
+}
+
+func (s *journalStore) CreationTime() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.creationTime
+}
+
+func (s *journalStore) SetCreationTime(t time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.appendLine("C %d", t.UnixNano()); err == nil {
+		s.creationTime = t
+	}
+}
+
+func (s *journalStore) SaveMessage(seqNum int, msg []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.saveMessageLocked(seqNum, msg)
+}
+
+func (s *journalStore) saveMessageLocked(seqNum int, msg []byte) error {
+	header := fmt.Sprintf("M %d %d\n", seqNum, len(msg))
+	offset, err := s.file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	record := make([]byte, 0, len(header)+len(msg)+1)
+	record = append(record, header...)
+	record = append(record, msg...)
+	record = append(record, '\n')
+	if _, err := s.file.Write(record); err != nil {
+		return err
+	}
+	if err := s.file.Sync(); err != nil {
+		return err
+	}
+	s.messages[seqNum] = msgLoc{offset: offset + int64(len(header)), length: len(msg)}
+	return nil
+}
+
+func (s *journalStore) SaveMessageAndIncrNextSenderMsgSeqNum(seqNum int, msg []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.saveMessageLocked(seqNum, msg); err != nil {
+		return err
+	}
+	return s.setSenderLocked(s.senderSeq + 1)
+}
+
+func (s *journalStore) GetMessages(beginSeqNum, endSeqNum int) ([][]byte, error) {
+	var msgs [][]byte
+	err := s.IterateMessages(beginSeqNum, endSeqNum, func(msg []byte) error {
+		msgs = append(msgs, msg)
+		return nil
+	})
+	return msgs, err
+}
+
+func (s *journalStore) IterateMessages(beginSeqNum, endSeqNum int, cb func([]byte) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for seq := beginSeqNum; seq <= endSeqNum; seq++ {
+		loc, ok := s.messages[seq]
+		if !ok {
+			continue
+		}
+		msg := make([]byte, loc.length)
+		if _, err := s.file.ReadAt(msg, loc.offset); err != nil {
+			return err
+		}
+		if err := cb(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *journalStore) Refresh() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load()
+}
+
+// Reset starts a new session: the journal is truncated and sequence numbers
+// return to 1.
+func (s *journalStore) Reset() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.file.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := s.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	s.senderSeq, s.targetSeq = 1, 1
+	s.creationTime = time.Now().UTC()
+	s.messages = make(map[int]msgLoc)
+	return s.appendLine("C %d\nS 1\nT 1", s.creationTime.UnixNano())
+}
+
+func (s *journalStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
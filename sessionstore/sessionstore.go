@@ -0,0 +1,55 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sessionstore
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/quickfix/config"
+	"github.com/quickfixgo/quickfix/store/file"
+)
+
+const (
+	TypeMemory  = "memory"
+	TypeFile    = "file"
+	TypeJournal = "journal"
+)
+
+// NewFactory returns the FIX message store named by storeType. An empty
+// storeType selects the file store when FileStorePath is configured and the
+// memory store otherwise.
+func NewFactory(storeType string, settings *quickfix.Settings) (quickfix.MessageStoreFactory, error) {
+	if storeType == "" {
+		storeType = TypeMemory
+		for _, session := range settings.SessionSettings() {
+			if session.HasSetting(config.FileStorePath) {
+				storeType = TypeFile
+			}
+		}
+	}
+	switch strings.ToLower(storeType) {
+	case TypeMemory:
+		return quickfix.NewMemoryStoreFactory(), nil
+	case TypeFile:
+		return file.NewStoreFactory(settings), nil
+	case TypeJournal:
+		return NewJournalStoreFactory(settings), nil
+	}
+	return nil, fmt.Errorf("unknown session store %q (memory, file or journal)", storeType)
+}
@@ -0,0 +1,174 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+
+	"prime-fix-go/model"
+)
+
+// journalEntry is one line of the journal.
+type journalEntry struct {
+	Order *model.OrderInfo        `json:"order,omitempty"`
+	Fill  *model.Fill             `json:"fill,omitempty"`
+	Rfq   *model.QuoteRequestInfo `json:"rfq,omitempty"`
+}
+
+// JournalStore appends every saved order, fill and RFQ to a JSON-lines file
+// and rebuilds the current state by replaying it; the last entry for a ClOrdID
+// or QuoteReqID wins. A torn final line left by a crash is truncated on open.
+type JournalStore struct {
+	mu     sync.Mutex
+	file   *os.File
+	orders map[string]model.OrderInfo
+	fills  []model.Fill
+	rfqs   map[string]model.QuoteRequestInfo
+}
+
+func NewJournalStore(path string) (*JournalStore, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	s := &JournalStore{
+		file:   f,
+		orders: make(map[string]model.OrderInfo),
+		rfqs:   make(map[string]model.QuoteRequestInfo),
+	}
+	if err := s.replay(); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to replay %s: %w", path, err)
+	}
+	return s, nil
+}
+
+func (s *JournalStore) replay() error {
+	reader := bufio.NewReader(s.file)
+	var offset int64
+	for lineNo := 1; ; lineNo++ {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			if len(bytes.TrimSpace(line)) > 0 {
+				log.Printf("⚠ %s: dropping incomplete final entry", s.file.Name())
+				if err := s.file.Truncate(offset); err != nil {
+					return err
+				}
+			}
+			break
+		}
+		if err != nil {
+			return err
+		}
+		offset += int64(len(line))
+
+		var entry journalEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			log.Printf("⚠ %s:%d: skipping unreadable entry: %v", s.file.Name(), lineNo, err)
+			continue
+		}
+		if entry.Order != nil {
+			s.orders[entry.Order.ClOrdId] = *entry.Order
+		}
+		if entry.Fill != nil {
+			s.fills = append(s.fills, *entry.Fill)
+		}
+		if entry.Rfq != nil {
+			s.rfqs[entry.Rfq.QuoteReqId] = *entry.Rfq
+		}
+	}
+	_, err := s.file.Seek(0, io.SeekEnd)
+	return err
+}
+
+func (s *JournalStore) append(entry journalEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := s.file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return s.file.Sync()
+}
+
+func (s *JournalStore) LoadOrders() (map[string]model.OrderInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	orders := make(map[string]model.OrderInfo, len(s.orders))
+	for k, v := range s.orders {
+		orders[k] = v
+	}
+	return orders, nil
+}
+
+func (s *JournalStore) SaveOrder(info model.OrderInfo) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.append(journalEntry{Order: &info}); err != nil {
+		return err
+	}
+	s.orders[info.ClOrdId] = info
+	return nil
+}
+
+func (s *JournalStore) LoadFills() ([]model.Fill, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]model.Fill(nil), s.fills...), nil
+}
+
+func (s *JournalStore) SaveFill(fill model.Fill) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.append(journalEntry{Fill: &fill}); err != nil {
+		return err
+	}
+	s.fills = append(s.fills, fill)
+	return nil
+}
+
+func (s *JournalStore) LoadRfqs() (map[string]model.QuoteRequestInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rfqs := make(map[string]model.QuoteRequestInfo, len(s.rfqs))
+	for k, v := range s.rfqs {
+		rfqs[k] = v
+	}
+	return rfqs, nil
+}
+
+func (s *JournalStore) SaveRfq(rfq model.QuoteRequestInfo) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.append(journalEntry{Rfq: &rfq}); err != nil {
+		return err
+	}
+	s.rfqs[rfq.QuoteReqId] = rfq
+	return nil
+}
+
+func (s *JournalStore) Close() error {
+	return s.file.Close()
+}
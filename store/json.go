@@ -0,0 +1,205 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"prime-fix-go/model"
+)
+
+// JsonStore keeps the order cache as a single JSON map, with the fill ledger
+// and RFQs in their own files beside it. Every save rewrites the file
+// atomically and keeps the previous version as a .bak, which is used if the
+// main file is found corrupt.
+type JsonStore struct {
+	mu        sync.Mutex
+	path      string
+	fillsPath string
+	rfqsPath  string
+	orders    map[string]model.OrderInfo
+	fills     []model.Fill
+	rfqs      map[string]model.QuoteRequestInfo
+}
+
+func NewJsonStore(path string) (*JsonStore, error) {
+	s := &JsonStore{
+		path:      path,
+		fillsPath: siblingPath(path, "fills"),
+		rfqsPath:  siblingPath(path, "rfqs"),
+		orders:    make(map[string]model.OrderInfo),
+	}
+	if err := readJsonFile(s.path, &s.orders); err != nil {
+		return nil, err
+	}
+	if err := readJsonFile(s.fillsPath, &s.fills); err != nil {
+		return nil, err
+	}
+	if err := readJsonFile(s.rfqsPath, &s.rfqs); err != nil {
+		return nil, err
+	}
+	if s.orders == nil {
+		s.orders = make(map[string]model.OrderInfo)
+	}
+	if s.rfqs == nil {
+		s.rfqs = make(map[string]model.QuoteRequestInfo)
+	}
+	return s, nil
+}
+
+func (s *JsonStore) LoadOrders() (map[string]model.OrderInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	orders := make(map[string]model.OrderInfo, len(s.orders))
+	for k, v := range s.orders {
+		orders[k] = v
+	}
+	return orders, nil
+}
+
+func (s *JsonStore) SaveOrder(info model.OrderInfo) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.orders[info.ClOrdId] = info
+	return writeJsonFile(s.path, s.orders)
+}
+
+func (s *JsonStore) LoadFills() ([]model.Fill, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]model.Fill(nil), s.fills...), nil
+}
+
+func (s *JsonStore) SaveFill(fill model.Fill) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fills = append(s.fills, fill)
+	return writeJsonFile(s.fillsPath, s.fills)
+}
+
+func (s *JsonStore) LoadRfqs() (map[string]model.QuoteRequestInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rfqs := make(map[string]model.QuoteRequestInfo, len(s.rfqs))
+	for k, v := range s.rfqs {
+		rfqs[k] = v
+	}
+	return rfqs, nil
+}
+
+func (s *JsonStore) SaveRfq(rfq model.QuoteRequestInfo) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rfqs[rfq.QuoteReqId] = rfq
+	return writeJsonFile(s.rfqsPath, s.rfqs)
+}
+
+func (s *JsonStore) Close() error {
+	return nil
+}
+
+// readJsonFile decodes path into v. A missing file leaves v untouched; a
+// corrupt file is set aside and the .bak copy is used instead.
+func readJsonFile(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if json.Valid(data) {
+		return json.Unmarshal(data, v)
+	}
+
+	corrupt := fmt.Sprintf("%s.corrupt-%d", path, time.Now().Unix())
+	log.Printf("⚠ %s is corrupt; moved to %s, recovering from backup", path, corrupt)
+	if err := os.Rename(path, corrupt); err != nil {
+		return fmt.Errorf("%s is corrupt and could not be moved aside: %w", path, err)
+	}
+
+	backup, err := os.ReadFile(path + ".bak")
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(backup, v); err != nil {
+		return fmt.Errorf("%s and its backup are both corrupt: %w", path, err)
+	}
+	return nil
+}
+
+// writeJsonFile replaces path with the encoding of v by writing a temporary
+// file in the same directory and renaming it over the original, so a crash
+// leaves either the old or the new version on disk.
+func writeJsonFile(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", path, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+
+	if err := copyFile(path, path+".bak"); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,89 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"sync"
+
+	"prime-fix-go/model"
+)
+
+// MemoryStore keeps everything in process memory; it is intended for tests.
+type MemoryStore struct {
+	mu     sync.Mutex
+	orders map[string]model.OrderInfo
+	fills  []model.Fill
+	rfqs   map[string]model.QuoteRequestInfo
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		orders: make(map[string]model.OrderInfo),
+		rfqs:   make(map[string]model.QuoteRequestInfo),
+	}
+}
+
+func (s *MemoryStore) LoadOrders() (map[string]model.OrderInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	orders := make(map[string]model.OrderInfo, len(s.orders))
+	for k, v := range s.orders {
+		orders[k] = v
+	}
+	return orders, nil
+}
+
+func (s *MemoryStore) SaveOrder(info model.OrderInfo) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.orders[info.ClOrdId] = info
+	return nil
+}
+
+func (s *MemoryStore) LoadFills() ([]model.Fill, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]model.Fill(nil), s.fills...), nil
+}
+
+func (s *MemoryStore) SaveFill(fill model.Fill) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fills = append(s.fills, fill)
+	return nil
+}
+
+func (s *MemoryStore) LoadRfqs() (map[string]model.QuoteRequestInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rfqs := make(map[string]model.QuoteRequestInfo, len(s.rfqs))
+	for k, v := range s.rfqs {
+		rfqs[k] = v
+	}
+	return rfqs, nil
+}
+
+func (s *MemoryStore) SaveRfq(rfq model.QuoteRequestInfo) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rfqs[rfq.QuoteReqId] = rfq
+	return nil
+}
+
+func (s *MemoryStore) Close() error {
+	return nil
+}
@@ -0,0 +1,74 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"prime-fix-go/model"
+)
+
+const (
+	TypeJson    = "json"
+	TypeJournal = "journal"
+	TypeMemory  = "memory"
+
+	DefaultJsonPath    = "orders.json"
+	DefaultJournalPath = "orders.journal"
+)
+
+// OrderStore persists the order cache, fill ledger and RFQs. Saves are per
+// record so that append-only backends do not rewrite history.
+type OrderStore interface {
+	LoadOrders() (map[string]model.OrderInfo, error)
+	SaveOrder(info model.OrderInfo) error
+	LoadFills() ([]model.Fill, error)
+	SaveFill(fill model.Fill) error
+	LoadRfqs() (map[string]model.QuoteRequestInfo, error)
+	SaveRfq(rfq model.QuoteRequestInfo) error
+	Close() error
+}
+
+// New opens the store named by storeType ("json", "journal" or "memory") at
+// path. An empty storeType selects the JSON store and an empty path selects the
+// backend's default file in the working directory.
+func New(storeType, path string) (OrderStore, error) {
+	switch strings.ToLower(storeType) {
+	case "", TypeJson:
+		if path == "" {
+			path = DefaultJsonPath
+		}
+		return NewJsonStore(path)
+	case TypeJournal:
+		if path == "" {
+			path = DefaultJournalPath
+		}
+		return NewJournalStore(path)
+	case TypeMemory:
+		return NewMemoryStore(), nil
+	}
+	return nil, fmt.Errorf("unknown order store %q (json, journal or memory)", storeType)
+}
+
+// siblingPath derives the path of a companion file, e.g. orders.json →
+// orders.fills.json.
+func siblingPath(path, name string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + name + ext
+}
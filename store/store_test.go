@@ -0,0 +1,122 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"prime-fix-go/model"
+)
+
+func TestJsonStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "orders.json")
+	s, err := NewJsonStore(path)
+	if err != nil {
+		t.Fatalf("NewJsonStore returned error: %v", err)
+	}
+	_ = s.SaveOrder(model.OrderInfo{ClOrdId: "1", Status: model.OrderStatusNew})
+	_ = s.SaveOrder(model.OrderInfo{ClOrdId: "1", Status: model.OrderStatusFilled})
+	_ = s.SaveFill(model.Fill{ExecId: "e1"})
+	_ = s.SaveRfq(model.QuoteRequestInfo{QuoteReqId: "qr-1", Status: model.RfqStatusQuoted})
+
+	reopened, err := NewJsonStore(path)
+	if err != nil {
+		t.Fatalf("NewJsonStore returned error: %v", err)
+	}
+	orders, _ := reopened.LoadOrders()
+	if orders["1"].Status != model.OrderStatusFilled {
+		t.Errorf("Expected status %s, got %s", model.OrderStatusFilled, orders["1"].Status)
+	}
+	fills, _ := reopened.LoadFills()
+	if len(fills) != 1 {
+		t.Errorf("Expected 1 fill, got %d", len(fills))
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(path), "orders.fills.json")); err != nil {
+		t.Errorf("Expected fills beside the order cache: %v", err)
+	}
+	rfqs, _ := reopened.LoadRfqs()
+	if rfqs["qr-1"].Status != model.RfqStatusQuoted {
+		t.Errorf("Expected RFQ status %s, got %s", model.RfqStatusQuoted, rfqs["qr-1"].Status)
+	}
+}
+
+func TestJsonStoreRecoversFromBackup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "orders.json")
+	s, _ := NewJsonStore(path)
+	_ = s.SaveOrder(model.OrderInfo{ClOrdId: "1"})
+	_ = s.SaveOrder(model.OrderInfo{ClOrdId: "2"})
+
+	if err := os.WriteFile(path, []byte(`{"1": {"clOrdId": "1"`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	recovered, err := NewJsonStore(path)
+	if err != nil {
+		t.Fatalf("NewJsonStore returned error: %v", err)
+	}
+	orders, _ := recovered.LoadOrders()
+	if _, ok := orders["1"]; !ok || len(orders) != 1 {
+		t.Errorf("Expected the backup with order 1, got %v", orders)
+	}
+}
+
+func TestJournalStoreReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "orders.journal")
+	s, err := NewJournalStore(path)
+	if err != nil {
+		t.Fatalf("NewJournalStore returned error: %v", err)
+	}
+	_ = s.SaveOrder(model.OrderInfo{ClOrdId: "1", Status: model.OrderStatusNew})
+	_ = s.SaveOrder(model.OrderInfo{ClOrdId: "1", Status: model.OrderStatusCanceled})
+	_ = s.SaveFill(model.Fill{ExecId: "e1"})
+	_ = s.SaveRfq(model.QuoteRequestInfo{QuoteReqId: "qr-1", Status: model.RfqStatusSent})
+	_ = s.SaveRfq(model.QuoteRequestInfo{QuoteReqId: "qr-1", Status: model.RfqStatusAcked})
+	_ = s.Close()
+
+	// Simulate a crash part-way through an append.
+	f, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	_, _ = f.WriteString(`{"order":{"clOrdId":"2"`)
+	_ = f.Close()
+
+	reopened, err := NewJournalStore(path)
+	if err != nil {
+		t.Fatalf("NewJournalStore returned error: %v", err)
+	}
+	defer reopened.Close()
+	orders, _ := reopened.LoadOrders()
+	if len(orders) != 1 || orders["1"].Status != model.OrderStatusCanceled {
+		t.Errorf("Expected order 1 CANCELED only, got %v", orders)
+	}
+	fills, _ := reopened.LoadFills()
+	if len(fills) != 1 {
+		t.Errorf("Expected 1 fill, got %d", len(fills))
+	}
+	rfqs, _ := reopened.LoadRfqs()
+	if len(rfqs) != 1 || rfqs["qr-1"].Status != model.RfqStatusAcked {
+		t.Errorf("Expected RFQ qr-1 ACKED only, got %v", rfqs)
+	}
+
+	_ = reopened.SaveOrder(model.OrderInfo{ClOrdId: "3"})
+	again, _ := NewJournalStore(path)
+	defer again.Close()
+	orders, _ = again.LoadOrders()
+	if _, ok := orders["3"]; !ok {
+		t.Error("Expected appends after a truncated entry to replay")
+	}
+}
@@ -20,7 +20,6 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
-	"os"
 
 	"github.com/quickfixgo/quickfix"
 )
@@ -33,8 +32,8 @@ func GetString(msg *quickfix.Message, tag quickfix.Tag) string {
 	return v
 }
 
-func Sign(ts, msgType, seq, key, tgt, pass, secret string) string {
-	mac := hmac.New(sha256.New, []byte(secret))
+func Sign(ts, msgType, seq, key, tgt, pass string, secret []byte) string {
+	mac := hmac.New(sha256.New, secret)
 	mac.Write([]byte(ts + msgType + seq + key + tgt + pass))
 	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
 }
@@ -45,12 +44,3 @@ func GetOptional(parts []string, idx int) string {
 	}
 	return ""
 }
-
-func LoadSettings(path string) (*quickfix.Settings, error) {
-	f, err := os.Open(path)
-	if err != nil {
-		return nil, err
-	}
-	defer f.Close()
-	return quickfix.ParseSettings(f)
-}